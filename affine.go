@@ -0,0 +1,212 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import "math"
+
+// Affine2 is a 2D affine transform. It maps (x, y) to
+// (A*x + B*y + C, D*x + E*y + F), i.e. it is the top two rows of the 3x3
+// matrix [A B C; D E F; 0 0 1].
+type Affine2 struct{ A, B, C, D, E, F float64 }
+
+// Identity returns the identity transform.
+func Identity() Affine2 { return Affine2{A: 1, E: 1} }
+
+// Translation returns a transform that adds v.
+func Translation(v F2) Affine2 { return Affine2{A: 1, C: v.X, E: 1, F: v.Y} }
+
+// Rotation returns a transform that rotates by the angle t about the
+// origin, in the same sense as F2.Rot.
+func Rotation(t float64) Affine2 {
+	s, c := math.Sincos(t)
+	return Affine2{A: c, B: -s, D: s, E: c}
+}
+
+// RotationAbout returns a transform that rotates by the angle t about b,
+// in the same sense as F2.RotAbout.
+func RotationAbout(t float64, b F2) Affine2 {
+	return Translation(b).Compose(Rotation(t)).Compose(Translation(b.Mul(-1)))
+}
+
+// Scaling returns a transform that multiplies the X and Y components by
+// k.X and k.Y respectively.
+func Scaling(k F2) Affine2 { return Affine2{A: k.X, E: k.Y} }
+
+// Shearing returns a transform that maps (x, y) to (x + k.X*y, y + k.Y*x).
+func Shearing(k F2) Affine2 { return Affine2{A: 1, B: k.X, D: k.Y, E: 1} }
+
+// Reflection returns a transform that reflects in the line through the
+// origin parallel to v.
+func Reflection(v F2) Affine2 {
+	u := v.Unit()
+	xx, yy, xy := u.X*u.X, u.Y*u.Y, u.X*u.Y
+	return Affine2{A: xx - yy, B: 2 * xy, D: 2 * xy, E: yy - xx}
+}
+
+// Compose returns the transform that applies n, then m (the matrix product m*n).
+func (m Affine2) Compose(n Affine2) Affine2 {
+	return Affine2{
+		A: m.A*n.A + m.B*n.D,
+		B: m.A*n.B + m.B*n.E,
+		C: m.A*n.C + m.B*n.F + m.C,
+		D: m.D*n.A + m.E*n.D,
+		E: m.D*n.B + m.E*n.E,
+		F: m.D*n.C + m.E*n.F + m.F,
+	}
+}
+
+// Det returns the determinant of the linear part of m.
+func (m Affine2) Det() float64 { return m.A*m.E - m.B*m.D }
+
+// Invert returns the inverse transform, or false if m is singular.
+func (m Affine2) Invert() (Affine2, bool) {
+	det := m.Det()
+	if det == 0 {
+		return Affine2{}, false
+	}
+	a, b, d, e := m.E/det, -m.B/det, -m.D/det, m.A/det
+	return Affine2{
+		A: a, B: b, C: -(a*m.C + b*m.F),
+		D: d, E: e, F: -(d*m.C + e*m.F),
+	}, true
+}
+
+// Apply transforms v.
+func (m Affine2) Apply(v F2) F2 {
+	return F2{m.A*v.X + m.B*v.Y + m.C, m.D*v.X + m.E*v.Y + m.F}
+}
+
+// ApplyLinear transforms v without the translation part, which is the
+// appropriate way to transform directions and displacements.
+func (m Affine2) ApplyLinear(v F2) F2 {
+	return F2{m.A*v.X + m.B*v.Y, m.D*v.X + m.E*v.Y}
+}
+
+// Rect returns the smallest Rect containing the image of r under m.
+// The corners of the image are rounded outwards. r is treated as the
+// continuous region between its corners, whereas IAffine2.Rect maps the
+// points contained in r, so the two differ for rotations and flips: for
+// r = NewRect(0, 0, 4, 2), IRotation(1).Affine2().Rect(r) is
+// (-2,0)-(0,4), but IRotation(1).Rect(r) is (-1,0)-(1,4).
+func (m Affine2) Rect(r Rect) Rect {
+	lo, hi := F2{math.Inf(1), math.Inf(1)}, F2{math.Inf(-1), math.Inf(-1)}
+	for _, p := range [4]I2{r.UL, {r.DR.X, r.UL.Y}, {r.UL.X, r.DR.Y}, r.DR} {
+		q := m.Apply(p.F2())
+		lo, hi = lo.ClampHi(q), hi.ClampLo(q)
+	}
+	return NewRect(
+		int(math.Floor(lo.X)), int(math.Floor(lo.Y)),
+		int(math.Ceil(hi.X)), int(math.Ceil(hi.Y)),
+	)
+}
+
+// IAffine2 is an affine transform with integer coefficients, which maps
+// I2 to I2 exactly. It maps (x, y) to (A*x + B*y + C, D*x + E*y + F).
+// It is mainly useful for translations, 90 degree rotations and flips.
+type IAffine2 struct{ A, B, C, D, E, F int }
+
+// IIdentity returns the identity transform.
+func IIdentity() IAffine2 { return IAffine2{A: 1, E: 1} }
+
+// ITranslation returns a transform that adds v.
+func ITranslation(v I2) IAffine2 { return IAffine2{A: 1, C: v.X, E: 1, F: v.Y} }
+
+// IRotation returns a transform that rotates by n quarter turns about the
+// origin, in the same sense as Rotation (so IRotation(1) maps v to v.Normal()).
+func IRotation(n int) IAffine2 {
+	switch n & 3 {
+	case 1:
+		return IAffine2{B: -1, D: 1}
+	case 2:
+		return IAffine2{A: -1, E: -1}
+	case 3:
+		return IAffine2{B: 1, D: -1}
+	default:
+		return IIdentity()
+	}
+}
+
+// IFlipX returns a transform that negates the X component.
+func IFlipX() IAffine2 { return IAffine2{A: -1, E: 1} }
+
+// IFlipY returns a transform that negates the Y component.
+func IFlipY() IAffine2 { return IAffine2{A: 1, E: -1} }
+
+// ITranspose returns a transform that swaps the X and Y components.
+func ITranspose() IAffine2 { return IAffine2{B: 1, D: 1} }
+
+// Compose returns the transform that applies n, then m (the matrix product m*n).
+func (m IAffine2) Compose(n IAffine2) IAffine2 {
+	return IAffine2{
+		A: m.A*n.A + m.B*n.D,
+		B: m.A*n.B + m.B*n.E,
+		C: m.A*n.C + m.B*n.F + m.C,
+		D: m.D*n.A + m.E*n.D,
+		E: m.D*n.B + m.E*n.E,
+		F: m.D*n.C + m.E*n.F + m.F,
+	}
+}
+
+// Det returns the determinant of the linear part of m.
+func (m IAffine2) Det() int { return m.A*m.E - m.B*m.D }
+
+// Invert returns the inverse transform, or false if it does not have
+// integer coefficients (i.e. the determinant is not 1 or -1).
+func (m IAffine2) Invert() (IAffine2, bool) {
+	det := m.Det()
+	if det != 1 && det != -1 {
+		return IAffine2{}, false
+	}
+	// Dividing by det is the same as multiplying by it.
+	a, b, d, e := m.E*det, -m.B*det, -m.D*det, m.A*det
+	return IAffine2{
+		A: a, B: b, C: -(a*m.C + b*m.F),
+		D: d, E: e, F: -(d*m.C + e*m.F),
+	}, true
+}
+
+// Apply transforms v.
+func (m IAffine2) Apply(v I2) I2 {
+	return I2{m.A*v.X + m.B*v.Y + m.C, m.D*v.X + m.E*v.Y + m.F}
+}
+
+// Rect returns the smallest Rect containing the images of all the points
+// contained in r. Since Rect is half-open, the last point contained in r
+// is r.DR - (1,1), and an empty r is mapped to an empty Rect. This treats
+// r as a set of cells, unlike Affine2.Rect, which maps the continuous
+// region between the corners of r: for r = NewRect(0, 0, 4, 2),
+// IRotation(1).Rect(r) is (-1,0)-(1,4), but IRotation(1).Affine2().Rect(r)
+// is (-2,0)-(0,4).
+func (m IAffine2) Rect(r Rect) Rect {
+	if r.DR.X <= r.UL.X || r.DR.Y <= r.UL.Y {
+		p := m.Apply(r.UL)
+		return Rect{UL: p, DR: p}
+	}
+	last := r.DR.Sub(I2{1, 1})
+	lo, hi := m.Apply(r.UL), m.Apply(r.UL)
+	for _, p := range [3]I2{{last.X, r.UL.Y}, {r.UL.X, last.Y}, last} {
+		q := m.Apply(p)
+		lo, hi = lo.ClampHi(q), hi.ClampLo(q)
+	}
+	return Rect{UL: lo, DR: hi.Add(I2{1, 1})}
+}
+
+// Affine2 converts m to an equivalent Affine2.
+func (m IAffine2) Affine2() Affine2 {
+	return Affine2{
+		A: float64(m.A), B: float64(m.B), C: float64(m.C),
+		D: float64(m.D), E: float64(m.E), F: float64(m.F),
+	}
+}
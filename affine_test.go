@@ -0,0 +1,90 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func near(v, w F2) bool {
+	d := v.Sub(w)
+	return math.Abs(d.X) < 1e-9 && math.Abs(d.Y) < 1e-9
+}
+
+func TestAffine2(t *testing.T) {
+	tests := []struct {
+		m    Affine2
+		v    F2
+		want F2
+	}{
+		{Identity(), F2{3, 4}, F2{3, 4}},
+		{Translation(F2{1, -1}), F2{3, 4}, F2{4, 3}},
+		{Rotation(math.Pi / 2), F2{1, 0}, F2{0, 1}},
+		{RotationAbout(math.Pi, F2{1, 1}), F2{0, 0}, F2{2, 2}},
+		{Scaling(F2{2, 3}), F2{1, 1}, F2{2, 3}},
+		{Shearing(F2{1, 0}), F2{1, 2}, F2{3, 2}},
+		{Reflection(F2{1, 1}), F2{2, 0}, F2{0, 2}},
+		{Translation(F2{5, 0}).Compose(Rotation(math.Pi / 2)), F2{1, 0}, F2{5, 1}},
+	}
+	for i, test := range tests {
+		if got := test.m.Apply(test.v); !near(got, test.want) {
+			t.Errorf("Affine2 test #%d: got %v want %v", i, got, test.want)
+		}
+		inv, ok := test.m.Invert()
+		if !ok {
+			t.Errorf("Affine2 test #%d: not invertible", i)
+			continue
+		}
+		if got := inv.Apply(test.want); !near(got, test.v) {
+			t.Errorf("Affine2 test #%d: inverse got %v want %v", i, got, test.v)
+		}
+	}
+	if _, ok := Scaling(F2{0, 1}).Invert(); ok {
+		t.Errorf("Scaling(0, 1).Invert() = _, true, want false")
+	}
+	if got, want := Rotation(math.Pi/4).Rect(NewRect(0, 0, 2, 2)), NewRect(-2, 0, 2, 3); got != want {
+		t.Errorf("Rect: got %v want %v", got, want)
+	}
+}
+
+func TestIAffine2(t *testing.T) {
+	for n := 0; n < 4; n++ {
+		if got, want := IRotation(n).Affine2().Apply(F2{3, 1}), Rotation(float64(n)*math.Pi/2).Apply(F2{3, 1}); !near(got, want) {
+			t.Errorf("IRotation(%d): got %v want %v", n, got, want)
+		}
+	}
+	m := ITranslation(I2{10, 0}).Compose(IRotation(1)).Compose(IFlipX())
+	if got, want := m.Apply(I2{2, 3}), (I2{7, -2}); got != want {
+		t.Errorf("Apply: got %v want %v", got, want)
+	}
+	inv, ok := m.Invert()
+	if !ok {
+		t.Fatalf("Invert: not invertible")
+	}
+	if got, want := inv.Apply(I2{7, -2}), (I2{2, 3}); got != want {
+		t.Errorf("inverse Apply: got %v want %v", got, want)
+	}
+	if got, want := IRotation(1).Rect(NewRect(0, 0, 4, 2)), NewRect(-1, 0, 1, 4); got != want {
+		t.Errorf("Rect: got %v want %v", got, want)
+	}
+	// Affine2.Rect maps the continuous region, not the cells.
+	if got, want := IRotation(1).Affine2().Rect(NewRect(0, 0, 4, 2)), NewRect(-2, 0, 0, 4); got != want {
+		t.Errorf("Affine2().Rect: got %v want %v", got, want)
+	}
+	if got, want := ITranspose().Rect(NewRect(1, 2, 4, 3)), NewRect(2, 1, 3, 4); got != want {
+		t.Errorf("Rect: got %v want %v", got, want)
+	}
+}
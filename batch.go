@@ -0,0 +1,257 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+// This file has batch operations over slices of points. They modify the
+// slice in place. The loops are unrolled four points at a time only so
+// that, with the slice bounds fixed up front, the compiler can drop bounds
+// checks inside the loop; the Go compiler does not vectorise them.
+// Convert a []I2 or []F2 with Points(s) or FPoints(s); this does not copy.
+
+// FPoints is a list of F2 points.
+type FPoints []F2
+
+// Translate adds d to every point.
+func (p FPoints) Translate(d F2) {
+	i := 0
+	for ; i+4 <= len(p); i += 4 {
+		s := p[i : i+4 : i+4]
+		s[0].X += d.X
+		s[0].Y += d.Y
+		s[1].X += d.X
+		s[1].Y += d.Y
+		s[2].X += d.X
+		s[2].Y += d.Y
+		s[3].X += d.X
+		s[3].Y += d.Y
+	}
+	for ; i < len(p); i++ {
+		p[i].X += d.X
+		p[i].Y += d.Y
+	}
+}
+
+// AddAll adds q[i] to p[i] for every point in p. It panics if q is shorter
+// than p.
+func (p FPoints) AddAll(q FPoints) {
+	q = q[:len(p)]
+	i := 0
+	for ; i+4 <= len(p); i += 4 {
+		s, t := p[i:i+4:i+4], q[i:i+4:i+4]
+		s[0].X += t[0].X
+		s[0].Y += t[0].Y
+		s[1].X += t[1].X
+		s[1].Y += t[1].Y
+		s[2].X += t[2].X
+		s[2].Y += t[2].Y
+		s[3].X += t[3].X
+		s[3].Y += t[3].Y
+	}
+	for ; i < len(p); i++ {
+		p[i].X += q[i].X
+		p[i].Y += q[i].Y
+	}
+}
+
+// ScaleAll multiplies every point by k.
+func (p FPoints) ScaleAll(k float64) {
+	i := 0
+	for ; i+4 <= len(p); i += 4 {
+		s := p[i : i+4 : i+4]
+		s[0].X *= k
+		s[0].Y *= k
+		s[1].X *= k
+		s[1].Y *= k
+		s[2].X *= k
+		s[2].Y *= k
+		s[3].X *= k
+		s[3].Y *= k
+	}
+	for ; i < len(p); i++ {
+		p[i].X *= k
+		p[i].Y *= k
+	}
+}
+
+// Transform applies m to every point.
+func (p FPoints) Transform(m Affine2) {
+	i := 0
+	for ; i+4 <= len(p); i += 4 {
+		s := p[i : i+4 : i+4]
+		s[0].X, s[0].Y = m.A*s[0].X+m.B*s[0].Y+m.C, m.D*s[0].X+m.E*s[0].Y+m.F
+		s[1].X, s[1].Y = m.A*s[1].X+m.B*s[1].Y+m.C, m.D*s[1].X+m.E*s[1].Y+m.F
+		s[2].X, s[2].Y = m.A*s[2].X+m.B*s[2].Y+m.C, m.D*s[2].X+m.E*s[2].Y+m.F
+		s[3].X, s[3].Y = m.A*s[3].X+m.B*s[3].Y+m.C, m.D*s[3].X+m.E*s[3].Y+m.F
+	}
+	for ; i < len(p); i++ {
+		p[i] = m.Apply(p[i])
+	}
+}
+
+// DotAll stores p[i] dot q[i] into dst[i] for every point in p, and returns
+// dst. If dst is too short, a new slice is allocated. It panics if q is
+// shorter than p.
+func (p FPoints) DotAll(q FPoints, dst []float64) []float64 {
+	if len(dst) < len(p) {
+		dst = make([]float64, len(p))
+	}
+	q, dst = q[:len(p)], dst[:len(p)]
+	i := 0
+	for ; i+4 <= len(p); i += 4 {
+		s, t, d := p[i:i+4:i+4], q[i:i+4:i+4], dst[i:i+4:i+4]
+		d[0] = s[0].X*t[0].X + s[0].Y*t[0].Y
+		d[1] = s[1].X*t[1].X + s[1].Y*t[1].Y
+		d[2] = s[2].X*t[2].X + s[2].Y*t[2].Y
+		d[3] = s[3].X*t[3].X + s[3].Y*t[3].Y
+	}
+	for ; i < len(p); i++ {
+		dst[i] = p[i].X*q[i].X + p[i].Y*q[i].Y
+	}
+	return dst
+}
+
+// BoundingBox returns the smallest FRect whose closure contains all the
+// points. Points with the maximum coordinates lie on the DR edges, so use
+// ContainsWith(v, Closed) to test them. For an empty list it returns the
+// zero FRect.
+func (p FPoints) BoundingBox() FRect {
+	if len(p) == 0 {
+		return FRect{}
+	}
+	lo, hi := p[0], p[0]
+	for _, v := range p[1:] {
+		lo.X = min(lo.X, v.X)
+		lo.Y = min(lo.Y, v.Y)
+		hi.X = max(hi.X, v.X)
+		hi.Y = max(hi.Y, v.Y)
+	}
+	return FRect{UL: lo, DR: hi}
+}
+
+// Translate adds d to every point.
+func (p Points) Translate(d I2) {
+	i := 0
+	for ; i+4 <= len(p); i += 4 {
+		s := p[i : i+4 : i+4]
+		s[0].X += d.X
+		s[0].Y += d.Y
+		s[1].X += d.X
+		s[1].Y += d.Y
+		s[2].X += d.X
+		s[2].Y += d.Y
+		s[3].X += d.X
+		s[3].Y += d.Y
+	}
+	for ; i < len(p); i++ {
+		p[i].X += d.X
+		p[i].Y += d.Y
+	}
+}
+
+// AddAll adds q[i] to p[i] for every point in p. It panics if q is shorter
+// than p.
+func (p Points) AddAll(q Points) {
+	q = q[:len(p)]
+	i := 0
+	for ; i+4 <= len(p); i += 4 {
+		s, t := p[i:i+4:i+4], q[i:i+4:i+4]
+		s[0].X += t[0].X
+		s[0].Y += t[0].Y
+		s[1].X += t[1].X
+		s[1].Y += t[1].Y
+		s[2].X += t[2].X
+		s[2].Y += t[2].Y
+		s[3].X += t[3].X
+		s[3].Y += t[3].Y
+	}
+	for ; i < len(p); i++ {
+		p[i].X += q[i].X
+		p[i].Y += q[i].Y
+	}
+}
+
+// ScaleAll multiplies every point by k.
+func (p Points) ScaleAll(k int) {
+	i := 0
+	for ; i+4 <= len(p); i += 4 {
+		s := p[i : i+4 : i+4]
+		s[0].X *= k
+		s[0].Y *= k
+		s[1].X *= k
+		s[1].Y *= k
+		s[2].X *= k
+		s[2].Y *= k
+		s[3].X *= k
+		s[3].Y *= k
+	}
+	for ; i < len(p); i++ {
+		p[i].X *= k
+		p[i].Y *= k
+	}
+}
+
+// Transform applies m to every point.
+func (p Points) Transform(m IAffine2) {
+	i := 0
+	for ; i+4 <= len(p); i += 4 {
+		s := p[i : i+4 : i+4]
+		s[0].X, s[0].Y = m.A*s[0].X+m.B*s[0].Y+m.C, m.D*s[0].X+m.E*s[0].Y+m.F
+		s[1].X, s[1].Y = m.A*s[1].X+m.B*s[1].Y+m.C, m.D*s[1].X+m.E*s[1].Y+m.F
+		s[2].X, s[2].Y = m.A*s[2].X+m.B*s[2].Y+m.C, m.D*s[2].X+m.E*s[2].Y+m.F
+		s[3].X, s[3].Y = m.A*s[3].X+m.B*s[3].Y+m.C, m.D*s[3].X+m.E*s[3].Y+m.F
+	}
+	for ; i < len(p); i++ {
+		p[i] = m.Apply(p[i])
+	}
+}
+
+// DotAll stores p[i] dot q[i] into dst[i] for every point in p, and returns
+// dst. If dst is too short, a new slice is allocated. It panics if q is
+// shorter than p.
+func (p Points) DotAll(q Points, dst []int64) []int64 {
+	if len(dst) < len(p) {
+		dst = make([]int64, len(p))
+	}
+	q, dst = q[:len(p)], dst[:len(p)]
+	i := 0
+	for ; i+4 <= len(p); i += 4 {
+		s, t, d := p[i:i+4:i+4], q[i:i+4:i+4], dst[i:i+4:i+4]
+		d[0] = int64(s[0].X)*int64(t[0].X) + int64(s[0].Y)*int64(t[0].Y)
+		d[1] = int64(s[1].X)*int64(t[1].X) + int64(s[1].Y)*int64(t[1].Y)
+		d[2] = int64(s[2].X)*int64(t[2].X) + int64(s[2].Y)*int64(t[2].Y)
+		d[3] = int64(s[3].X)*int64(t[3].X) + int64(s[3].Y)*int64(t[3].Y)
+	}
+	for ; i < len(p); i++ {
+		dst[i] = p[i].Dot(q[i])
+	}
+	return dst
+}
+
+// BoundingBox returns the smallest Rect containing all the points. Since
+// Rect is half-open, DR is one more than the maximum coordinates. For an
+// empty list it returns the zero Rect.
+func (p Points) BoundingBox() Rect {
+	if len(p) == 0 {
+		return Rect{}
+	}
+	lo, hi := p[0], p[0]
+	for _, v := range p[1:] {
+		lo.X = min(lo.X, v.X)
+		lo.Y = min(lo.Y, v.Y)
+		hi.X = max(hi.X, v.X)
+		hi.Y = max(hi.Y, v.Y)
+	}
+	return Rect{UL: lo, DR: hi.Add(I2{1, 1})}
+}
@@ -0,0 +1,168 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func randFPoints(n int) FPoints {
+	r := rand.New(rand.NewSource(1))
+	p := make(FPoints, n)
+	for i := range p {
+		p[i] = F2{r.Float64()*200 - 100, r.Float64()*200 - 100}
+	}
+	return p
+}
+
+func randPoints(n int) Points {
+	r := rand.New(rand.NewSource(1))
+	p := make(Points, n)
+	for i := range p {
+		p[i] = I2{r.Intn(200) - 100, r.Intn(200) - 100}
+	}
+	return p
+}
+
+func TestFPointsBatch(t *testing.T) {
+	// Odd length, to exercise the tail loops.
+	p, q := randFPoints(11), randFPoints(11)[1:]
+	m := RotationAbout(1, F2{3, 4})
+	want := make(FPoints, len(q))
+	for i, v := range q {
+		want[i] = m.Apply(v.Add(p[i]).Mul(2).Add(F2{1, -1}))
+	}
+	got := append(FPoints(nil), q...)
+	got.AddAll(p)
+	got.ScaleAll(2)
+	got.Translate(F2{1, -1})
+	got.Transform(m)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("batch ops: got %v, want %v", got, want)
+	}
+	dots := got.DotAll(p, nil)
+	for i := range dots {
+		if want := got[i].Dot(p[i]); dots[i] != want {
+			t.Errorf("DotAll[%d] = %f, want %f", i, dots[i], want)
+		}
+	}
+	if got, want := (FPoints{{1, 5}, {-2, 3}, {0, 7}}).BoundingBox(), NewFRect(-2, 3, 1, 7); got != want {
+		t.Errorf("BoundingBox = %v, want %v", got, want)
+	}
+	if got := FPoints(nil).BoundingBox(); got != (FRect{}) {
+		t.Errorf("BoundingBox(nil) = %v, want zero FRect", got)
+	}
+}
+
+func TestPointsBatch(t *testing.T) {
+	p, q := randPoints(11), randPoints(11)[1:]
+	m := ITranslation(I2{3, 4}).Compose(IRotation(1))
+	want := make(Points, len(q))
+	for i, v := range q {
+		want[i] = m.Apply(v.Add(p[i]).Mul(2).Add(I2{1, -1}))
+	}
+	got := append(Points(nil), q...)
+	got.AddAll(p)
+	got.ScaleAll(2)
+	got.Translate(I2{1, -1})
+	got.Transform(m)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("batch ops: got %v, want %v", got, want)
+	}
+	dots := got.DotAll(p, make([]int64, 100))
+	if len(dots) != len(got) {
+		t.Errorf("len(DotAll) = %d, want %d", len(dots), len(got))
+	}
+	for i := range dots {
+		if want := got[i].Dot(p[i]); dots[i] != want {
+			t.Errorf("DotAll[%d] = %d, want %d", i, dots[i], want)
+		}
+	}
+	if got, want := (Points{{1, 5}, {-2, 3}, {0, 7}}).BoundingBox(), NewRect(-2, 3, 2, 8); got != want {
+		t.Errorf("BoundingBox = %v, want %v", got, want)
+	}
+}
+
+const benchN = 1 << 16
+
+func BenchmarkF2AddLoop(b *testing.B) {
+	p := randFPoints(benchN)
+	d := F2{1, 2}
+	for i := 0; i < b.N; i++ {
+		for j := range p {
+			p[j] = p[j].Add(d)
+		}
+	}
+}
+
+func BenchmarkFPointsTranslate(b *testing.B) {
+	p := randFPoints(benchN)
+	for i := 0; i < b.N; i++ {
+		p.Translate(F2{1, 2})
+	}
+}
+
+func BenchmarkF2MulLoop(b *testing.B) {
+	p := randFPoints(benchN)
+	for i := 0; i < b.N; i++ {
+		for j := range p {
+			p[j] = p[j].Mul(1.0001)
+		}
+	}
+}
+
+func BenchmarkFPointsScaleAll(b *testing.B) {
+	p := randFPoints(benchN)
+	for i := 0; i < b.N; i++ {
+		p.ScaleAll(1.0001)
+	}
+}
+
+func BenchmarkAffine2ApplyLoop(b *testing.B) {
+	p := randFPoints(benchN)
+	m := Rotation(0.001)
+	for i := 0; i < b.N; i++ {
+		for j := range p {
+			p[j] = m.Apply(p[j])
+		}
+	}
+}
+
+func BenchmarkFPointsTransform(b *testing.B) {
+	p := randFPoints(benchN)
+	m := Rotation(0.001)
+	for i := 0; i < b.N; i++ {
+		p.Transform(m)
+	}
+}
+
+func BenchmarkI2AddLoop(b *testing.B) {
+	p := randPoints(benchN)
+	d := I2{1, 2}
+	for i := 0; i < b.N; i++ {
+		for j := range p {
+			p[j] = p[j].Add(d)
+		}
+	}
+}
+
+func BenchmarkPointsTranslate(b *testing.B) {
+	p := randPoints(benchN)
+	for i := 0; i < b.N; i++ {
+		p.Translate(I2{1, 2})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"errors"
+	"iter"
+	"math"
+	"math/rand"
+)
+
+// BSPConfig configures NewBSP.
+type BSPConfig struct {
+	MinRoom, MaxRoom I2 // the range of room sizes
+
+	// Margin is the minimum space between a room and the edge of its
+	// partition, so rooms in neighbouring partitions are at least
+	// 2*Margin apart.
+	Margin int
+
+	// Ratio limits how unevenly a partition may be split: each split is
+	// between Ratio and 1-Ratio of the way across (where room sizes
+	// allow). It should be between 0 and 0.5; if it is 0, 0.3 is used.
+	Ratio float64
+
+	Seed int64 // seed for the random number generator
+}
+
+// BSP is a node of a binary space partition tree. Leaf nodes each
+// contain a room.
+type BSP struct {
+	Rect     Rect    // the partition covered by this node
+	Room     Rect    // the room within Rect (leaves only)
+	Children [2]*BSP // the two halves of Rect, or nil for leaves
+}
+
+// NewBSP recursively splits r until every partition is small enough to
+// hold a room no larger than MaxRoom (or can't be split further), then
+// places a random room in each partition. The result depends only on r
+// and c. It returns an error if r is too small for even one room.
+func NewBSP(r Rect, c BSPConfig) (*BSP, error) {
+	if c.Ratio <= 0 {
+		c.Ratio = 0.3
+	}
+	margin := I2{2 * c.Margin, 2 * c.Margin}
+	minPart, maxPart := c.MinRoom.Add(margin), c.MaxRoom.Add(margin)
+	if sz := r.Size(); sz.X < minPart.X || sz.Y < minPart.Y {
+		return nil, errors.New("rect too small for a room")
+	}
+	if c.MinRoom.X > c.MaxRoom.X || c.MinRoom.Y > c.MaxRoom.Y {
+		return nil, errors.New("MinRoom larger than MaxRoom")
+	}
+	b := &bspBuilder{BSPConfig: c, minPart: minPart, maxPart: maxPart, rng: rand.New(rand.NewSource(c.Seed))}
+	return b.build(r), nil
+}
+
+// bspBuilder holds the state used while building a BSP.
+type bspBuilder struct {
+	BSPConfig
+	minPart, maxPart I2
+	rng              *rand.Rand
+}
+
+// between returns a random integer in [lo, hi].
+func (b *bspBuilder) between(lo, hi int) int { return lo + b.rng.Intn(hi-lo+1) }
+
+// cut returns a random position to split [lo, hi) at, given the minimum
+// partition length m.
+func (b *bspBuilder) cut(lo, hi, m int) int {
+	n := float64(hi - lo)
+	c0 := max(lo+m, lo+int(math.Ceil(b.Ratio*n)))
+	c1 := min(hi-m, lo+int(math.Floor((1-b.Ratio)*n)))
+	if c0 > c1 {
+		c0, c1 = lo+m, hi-m
+	}
+	return b.between(c0, c1)
+}
+
+func (b *bspBuilder) build(r Rect) *BSP {
+	sz := r.Size()
+	canX, canY := sz.X >= 2*b.minPart.X, sz.Y >= 2*b.minPart.Y
+	needX, needY := sz.X > b.maxPart.X, sz.Y > b.maxPart.Y
+	// Split the longer side that needs splitting, if possible.
+	splitX, splitY := canX && needX, canY && needY
+	if splitX && splitY {
+		switch {
+		case sz.X > sz.Y:
+			splitY = false
+		case sz.Y > sz.X:
+			splitX = false
+		default:
+			splitX = b.rng.Intn(2) == 0
+			splitY = !splitX
+		}
+	}
+
+	n := &BSP{Rect: r}
+	switch {
+	case splitX:
+		x := b.cut(r.UL.X, r.DR.X, b.minPart.X)
+		n.Children[0] = b.build(Rect{UL: r.UL, DR: I2{x, r.DR.Y}})
+		n.Children[1] = b.build(Rect{UL: I2{x, r.UL.Y}, DR: r.DR})
+	case splitY:
+		y := b.cut(r.UL.Y, r.DR.Y, b.minPart.Y)
+		n.Children[0] = b.build(Rect{UL: r.UL, DR: I2{r.DR.X, y}})
+		n.Children[1] = b.build(Rect{UL: I2{r.UL.X, y}, DR: r.DR})
+	default:
+		// Place a room, keeping Margin from the edges.
+		in := r.Expand(I2{-b.Margin, -b.Margin})
+		isz := in.Size()
+		room := I2{
+			b.between(b.MinRoom.X, min(b.MaxRoom.X, isz.X)),
+			b.between(b.MinRoom.Y, min(b.MaxRoom.Y, isz.Y)),
+		}
+		ul := I2{
+			b.between(in.UL.X, in.DR.X-room.X),
+			b.between(in.UL.Y, in.DR.Y-room.Y),
+		}
+		n.Room = Rect{UL: ul, DR: ul.Add(room)}
+	}
+	return n
+}
+
+// Leaf reports whether n is a leaf.
+func (n *BSP) Leaf() bool { return n.Children[0] == nil }
+
+// Leaves returns an iterator over the leaves under n, from the first child
+// to the second (so from left to right, or top to bottom).
+func (n *BSP) Leaves() iter.Seq[*BSP] {
+	return func(yield func(*BSP) bool) { n.leaves(yield) }
+}
+
+func (n *BSP) leaves(yield func(*BSP) bool) bool {
+	if n.Leaf() {
+		return yield(n)
+	}
+	return n.Children[0].leaves(yield) && n.Children[1].leaves(yield)
+}
+
+// Corridors returns a graph connecting the rooms, suitable for use as the
+// paths graph in FindPath. For each pair of sibling subtrees, it joins the
+// closest pair of rooms (one from each side) with an L-shaped corridor
+// between their centres. Edges are added in both directions.
+func (n *BSP) Corridors() *Graph {
+	g := NewGraph()
+	n.corridors(g)
+	return g
+}
+
+func (n *BSP) corridors(g *Graph) {
+	if n.Leaf() {
+		// Make sure rooms without corridors are still vertices.
+		if g.V == nil {
+			g.V = make(VertexSet)
+		}
+		g.V[n.Room.Center()] = true
+		return
+	}
+	n.Children[0].corridors(g)
+	n.Children[1].corridors(g)
+
+	// Find the closest pair of rooms across the split.
+	var a, b I2
+	best := int64(math.MaxInt64)
+	for l := range n.Children[0].Leaves() {
+		for r := range n.Children[1].Leaves() {
+			u, v := l.Room.Center(), r.Room.Center()
+			if d := v.Sub(u).Dot(v.Sub(u)); d < best {
+				a, b, best = u, v, d
+			}
+		}
+	}
+	// Go across the split first, then along it.
+	corner := I2{b.X, a.Y}
+	if n.Children[0].Rect.DR.Y == n.Children[1].Rect.UL.Y {
+		corner = I2{a.X, b.Y}
+	}
+	for _, e := range [][2]I2{{a, corner}, {corner, b}} {
+		if e[0] != e[1] {
+			g.AddEdge(e[0], e[1])
+			g.AddEdge(e[1], e[0])
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestBSP(t *testing.T) {
+	r := NewRect(0, 0, 80, 50)
+	for seed := int64(0); seed < 20; seed++ {
+		c := BSPConfig{MinRoom: I2{4, 3}, MaxRoom: I2{12, 8}, Margin: 1, Seed: seed}
+		root, err := NewBSP(r, c)
+		if err != nil {
+			t.Fatalf("NewBSP(seed %d): got error %v", seed, err)
+		}
+		again, _ := NewBSP(r, c)
+		if !reflect.DeepEqual(root, again) {
+			t.Errorf("NewBSP(seed %d): not deterministic", seed)
+		}
+
+		leaves := slices.Collect(root.Leaves())
+		if len(leaves) < 2 {
+			t.Errorf("NewBSP(seed %d): got %d leaves, want several", seed, len(leaves))
+		}
+		area := 0
+		for i, l := range leaves {
+			area += l.Rect.Area()
+			for _, m := range leaves[i+1:] {
+				if l.Rect.Overlaps(m.Rect) {
+					t.Errorf("NewBSP(seed %d): leaves %v and %v overlap", seed, l.Rect, m.Rect)
+				}
+			}
+			if sz := l.Rect.Size(); sz.X > 2*(c.MaxRoom.X+2) || sz.Y > 2*(c.MaxRoom.Y+2) {
+				t.Errorf("NewBSP(seed %d): leaf %v is too large", seed, l.Rect)
+			}
+			if !l.Room.In(l.Rect.Expand(I2{-c.Margin, -c.Margin})) {
+				t.Errorf("NewBSP(seed %d): room %v not within margin of %v", seed, l.Room, l.Rect)
+			}
+			sz := l.Room.Size()
+			if sz.X < c.MinRoom.X || sz.Y < c.MinRoom.Y || sz.X > c.MaxRoom.X || sz.Y > c.MaxRoom.Y {
+				t.Errorf("NewBSP(seed %d): room %v has size %v out of range", seed, l.Room, sz)
+			}
+		}
+		if area != r.Area() {
+			t.Errorf("NewBSP(seed %d): leaves cover area %d, want %d", seed, area, r.Area())
+		}
+
+		// Every room is reachable from every other.
+		g := root.Corridors()
+		start := leaves[0].Room.Center()
+		seen := VertexSet{start: true}
+		queue := []I2{start}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for v := range g.Neighbors(u) {
+				if !seen[v] {
+					seen[v] = true
+					queue = append(queue, v)
+				}
+			}
+		}
+		for _, l := range leaves {
+			if !seen[l.Room.Center()] {
+				t.Errorf("NewBSP(seed %d): room %v unreachable from %v", seed, l.Room, start)
+			}
+		}
+		for u, v := range g.EdgesSeq() {
+			if u.X != v.X && u.Y != v.Y {
+				t.Errorf("NewBSP(seed %d): corridor %v-%v not axis-aligned", seed, u, v)
+			}
+		}
+		end := leaves[len(leaves)-1].Room.Center()
+		if _, err := FindPath(NewGraph(), g, start, end, r); err != nil {
+			t.Errorf("NewBSP(seed %d): FindPath(%v, %v) error %v", seed, start, end, err)
+		}
+	}
+}
+
+func TestBSPSmall(t *testing.T) {
+	c := BSPConfig{MinRoom: I2{3, 3}, MaxRoom: I2{5, 5}, Margin: 1}
+	root, err := NewBSP(NewRect(0, 0, 6, 6), c)
+	if err != nil {
+		t.Fatalf("NewBSP: got error %v", err)
+	}
+	if !root.Leaf() || root.Room.Empty() {
+		t.Errorf("NewBSP: got %+v, want a single leaf with a room", root)
+	}
+	if g := root.Corridors(); len(g.V) != 1 || g.NumEdges() != 0 {
+		t.Errorf("Corridors: got %v, want one vertex and no edges", g)
+	}
+	if _, err := NewBSP(NewRect(0, 0, 4, 6), c); err == nil {
+		t.Errorf("NewBSP with too-small rect: got nil error")
+	}
+	c.MinRoom = I2{6, 6}
+	if _, err := NewBSP(NewRect(0, 0, 10, 10), c); err == nil {
+		t.Errorf("NewBSP with MinRoom > MaxRoom: got nil error")
+	}
+}
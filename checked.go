@@ -0,0 +1,172 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// ErrOverflow is returned by the checked functions when a result (or an
+// intermediate value) does not fit in an int64, or the final result does
+// not fit in an int.
+var ErrOverflow = errors.New("integer overflow")
+
+// checker does int64 arithmetic, remembering whether any operation overflowed.
+type checker struct{ overflow bool }
+
+func (c *checker) add(a, b int64) int64 {
+	s := a + b
+	if (a >= 0) == (b >= 0) && (s >= 0) != (a >= 0) {
+		c.overflow = true
+	}
+	return s
+}
+
+func (c *checker) sub(a, b int64) int64 {
+	s := a - b
+	if (a >= 0) != (b >= 0) && (s >= 0) != (a >= 0) {
+		c.overflow = true
+	}
+	return s
+}
+
+func (c *checker) mul(a, b int64) int64 {
+	ua, ub := uint64(a), uint64(b)
+	if a < 0 {
+		ua = -ua
+	}
+	if b < 0 {
+		ub = -ub
+	}
+	hi, lo := bits.Mul64(ua, ub)
+	if (a < 0) != (b < 0) {
+		if hi != 0 || lo > 1<<63 {
+			c.overflow = true
+		}
+		return -int64(lo)
+	}
+	if hi != 0 || lo > math.MaxInt64 {
+		c.overflow = true
+	}
+	return int64(lo)
+}
+
+// div returns a / b (truncated towards zero), noting an overflow for
+// MinInt64 / -1. b must not be 0.
+func (c *checker) div(a, b int64) int64 {
+	if a == math.MinInt64 && b == -1 {
+		c.overflow = true
+	}
+	return a / b
+}
+
+// toInt converts x to int, noting an overflow if it doesn't fit.
+func (c *checker) toInt(x int64) int {
+	if int64(int(x)) != x {
+		c.overflow = true
+	}
+	return int(x)
+}
+
+func (c *checker) i2(x, y int64) (I2, error) {
+	v := I2{c.toInt(x), c.toInt(y)}
+	if c.overflow {
+		return I2{}, ErrOverflow
+	}
+	return v, nil
+}
+
+// CmulChecked is like Cmul, but returns ErrOverflow instead of silently
+// overflowing.
+func (v I2) CmulChecked(w I2) (I2, error) {
+	var c checker
+	vx, vy := v.C64()
+	wx, wy := w.C64()
+	return c.i2(
+		c.sub(c.mul(wx, vx), c.mul(wy, vy)),
+		c.add(c.mul(wy, vx), c.mul(wx, vy)),
+	)
+}
+
+// LineIntersectIChecked is like LineIntersectI, but returns ErrOverflow
+// instead of silently overflowing. Intermediate values are computed in
+// int64, so it is exact for larger coordinates than LineIntersectI on
+// 32-bit platforms.
+func LineIntersectIChecked(p, q, a, b I2) (I2, bool, error) {
+	var c checker
+	px, py := p.C64()
+	qx, qy := q.C64()
+	ax, ay := a.C64()
+	bx, by := b.C64()
+	dx1, dy1, dx2, dy2 := c.sub(px, qx), c.sub(py, qy), c.sub(ax, bx), c.sub(ay, by)
+	det := c.sub(c.mul(dx2, dy1), c.mul(dx1, dy2))
+	if c.overflow {
+		return I2{}, false, ErrOverflow
+	}
+	if det == 0 {
+		return I2{}, false, nil
+	}
+	pq := c.sub(c.mul(qx, py), c.mul(px, qy))
+	ab := c.sub(c.mul(bx, ay), c.mul(ax, by))
+	x := c.sub(c.mul(pq, dx2), c.mul(ab, dx1))
+	y := c.sub(c.mul(pq, dy2), c.mul(ab, dy1))
+	v, err := c.i2(c.div(x, det), c.div(y, det))
+	return v, err == nil, err
+}
+
+// SegmentIntersectIChecked is like SegmentIntersectI, but returns
+// ErrOverflow instead of silently overflowing. Intermediate values are
+// computed in int64, so it is exact for larger coordinates than
+// SegmentIntersectI on 32-bit platforms.
+func SegmentIntersectIChecked(p, q, a, b I2) (I2, bool, error) {
+	var c checker
+	px, py := p.C64()
+	qx, qy := q.C64()
+	ax, ay := a.C64()
+	bx, by := b.C64()
+	dx1, dy1, dx2, dy2 := c.sub(px, qx), c.sub(py, qy), c.sub(ax, bx), c.sub(ay, by)
+	det := c.sub(c.mul(dy2, dx1), c.mul(dx2, dy1))
+	if c.overflow {
+		return I2{}, false, ErrOverflow
+	}
+	if det == 0 {
+		return I2{}, false, nil
+	}
+	sgn, adet := int64(1), det
+	if det < 0 {
+		sgn, adet = -1, c.sub(0, det)
+	}
+	apy := c.sub(ay, py)
+	t := c.mul(c.add(c.sub(c.mul(ax, c.sub(by, py)), c.mul(bx, apy)), c.mul(px, dy2)), sgn)
+	if c.overflow {
+		return I2{}, false, ErrOverflow
+	}
+	if t < 0 || t > adet {
+		return I2{}, false, nil
+	}
+	t = c.mul(c.sub(c.sub(c.mul(px, c.sub(ay, qy)), c.mul(qx, apy)), c.mul(ax, dy1)), sgn)
+	if c.overflow {
+		return I2{}, false, ErrOverflow
+	}
+	if t < 0 || t > adet {
+		return I2{}, false, nil
+	}
+	x := c.add(c.div(c.mul(c.sub(bx, ax), t), adet), ax)
+	y := c.add(c.div(c.mul(c.sub(by, ay), t), adet), ay)
+	v, err := c.i2(x, y)
+	return v, err == nil, err
+}
@@ -0,0 +1,71 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+	"testing"
+)
+
+func TestCheckedAgreesWhenSmall(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rnd := func() I2 { return I2{r.Intn(2000) - 1000, r.Intn(2000) - 1000} }
+	for i := 0; i < 1000; i++ {
+		p, q, a, b := rnd(), rnd(), rnd(), rnd()
+		wantP, want := SegmentIntersectI(p, q, a, b)
+		gotP, got, err := SegmentIntersectIChecked(p, q, a, b)
+		if err != nil || got != want || gotP != wantP {
+			t.Errorf("SegmentIntersectIChecked(%v, %v, %v, %v) = %v, %t, %v; want %v, %t, nil", p, q, a, b, gotP, got, err, wantP, want)
+		}
+		wantP, want = LineIntersectI(p, q, a, b)
+		gotP, got, err = LineIntersectIChecked(p, q, a, b)
+		if err != nil || got != want || gotP != wantP {
+			t.Errorf("LineIntersectIChecked(%v, %v, %v, %v) = %v, %t, %v; want %v, %t, nil", p, q, a, b, gotP, got, err, wantP, want)
+		}
+		if got, err := p.CmulChecked(q); err != nil || got != p.Cmul(q) {
+			t.Errorf("%v.CmulChecked(%v) = %v, %v; want %v, nil", p, q, got, err, p.Cmul(q))
+		}
+	}
+}
+
+func TestCheckedOverflow(t *testing.T) {
+	if bits.UintSize < 64 {
+		t.Skip("overflow cases are for 64-bit int")
+	}
+	h := math.MaxInt / 4
+	if _, err := (I2{h, 0}).CmulChecked(I2{h, 0}); err != ErrOverflow {
+		t.Errorf("CmulChecked: got err %v, want ErrOverflow", err)
+	}
+	if _, _, err := LineIntersectIChecked(I2{-h, -h}, I2{h, h}, I2{-h, h}, I2{h, -h}); err != ErrOverflow {
+		t.Errorf("LineIntersectIChecked: got err %v, want ErrOverflow", err)
+	}
+	if _, _, err := SegmentIntersectIChecked(I2{-h, -h}, I2{h, h}, I2{-h, h}, I2{h, -h}); err != ErrOverflow {
+		t.Errorf("SegmentIntersectIChecked: got err %v, want ErrOverflow", err)
+	}
+	// The products fit, but the numerator is MinInt64 and det is -1.
+	s := 31
+	p, q := I2{0, 1 << (s + 1)}, I2{-1 << s, 1<<(s+1) + 1}
+	if _, _, err := LineIntersectIChecked(p, q, I2{0, 0}, I2{-1, 0}); err != ErrOverflow {
+		t.Errorf("LineIntersectIChecked (MinInt64 / -1): got err %v, want ErrOverflow", err)
+	}
+	// Just inside the documented bound.
+	c := 1<<19 - 1
+	gotP, got, err := SegmentIntersectIChecked(I2{-c, -c}, I2{c, c}, I2{-c, c}, I2{c, -c})
+	if err != nil || !got || gotP != (I2{}) {
+		t.Errorf("SegmentIntersectIChecked at bound = %v, %t, %v; want (0,0), true, nil", gotP, got, err)
+	}
+}
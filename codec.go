@@ -0,0 +1,275 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+// This file implements a compact binary encoding for Points, EdgeList and
+// Graph. Every encoding starts with a 3-byte header: the magic byte 'v',
+// a byte identifying the type ('P', 'E' or 'G'), and a version byte
+// (currently 1). Coordinates are delta-encoded as zigzag varints
+// (encoding/binary's Varint), so nearby points take few bytes.
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const codecVersion = 1
+
+// Codec errors.
+var (
+	ErrCorrupt            = errors.New("truncated or corrupt data")
+	ErrUnsupportedVersion = errors.New("unsupported encoding version")
+)
+
+// Points is a list of points, with a compact binary encoding.
+type Points []I2
+
+// EdgeList is a list of edges, with a compact binary encoding.
+type EdgeList []Edge
+
+func appendHeader(b []byte, kind byte) []byte {
+	return append(b, 'v', kind, codecVersion)
+}
+
+func appendDelta(b []byte, v, prev I2) []byte {
+	b = binary.AppendVarint(b, int64(v.X-prev.X))
+	return binary.AppendVarint(b, int64(v.Y-prev.Y))
+}
+
+// decoder reads the binary encoding, remembering the first error.
+type decoder struct {
+	b   []byte
+	err error
+}
+
+func newDecoder(b []byte, kind byte) *decoder {
+	d := &decoder{b: b}
+	switch {
+	case len(b) < 3 || b[0] != 'v' || b[1] != kind:
+		d.err = ErrCorrupt
+	case b[2] != codecVersion:
+		d.err = ErrUnsupportedVersion
+	default:
+		d.b = b[3:]
+	}
+	return d
+}
+
+func (d *decoder) varint() int {
+	if d.err != nil {
+		return 0
+	}
+	x, n := binary.Varint(d.b)
+	if n <= 0 || int64(int(x)) != x {
+		d.err = ErrCorrupt
+		return 0
+	}
+	d.b = d.b[n:]
+	return int(x)
+}
+
+func (d *decoder) uvarint() int {
+	if d.err != nil {
+		return 0
+	}
+	x, n := binary.Uvarint(d.b)
+	if n <= 0 || x > math.MaxInt {
+		d.err = ErrCorrupt
+		return 0
+	}
+	d.b = d.b[n:]
+	return int(x)
+}
+
+// count reads a number of items to follow.
+func (d *decoder) count() int {
+	n := d.uvarint()
+	if n > len(d.b) {
+		// Every counted item takes at least one byte, so a count larger
+		// than the remaining input is corrupt (and shouldn't be allocated).
+		d.err = ErrCorrupt
+		return 0
+	}
+	return n
+}
+
+func (d *decoder) delta(prev I2) I2 {
+	x := d.varint()
+	y := d.varint()
+	return I2{prev.X + x, prev.Y + y}
+}
+
+func (d *decoder) finish() error {
+	if d.err == nil && len(d.b) != 0 {
+		d.err = ErrCorrupt
+	}
+	return d.err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. Each point is encoded
+// relative to the previous one.
+func (p Points) MarshalBinary() ([]byte, error) {
+	b := appendHeader(nil, 'P')
+	b = binary.AppendUvarint(b, uint64(len(p)))
+	var prev I2
+	for _, v := range p {
+		b = appendDelta(b, v, prev)
+		prev = v
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (p *Points) UnmarshalBinary(b []byte) error {
+	d := newDecoder(b, 'P')
+	n := d.count()
+	q := make(Points, 0, n)
+	var prev I2
+	for i := 0; i < n && d.err == nil; i++ {
+		prev = d.delta(prev)
+		q = append(q, prev)
+	}
+	if err := d.finish(); err != nil {
+		return fmt.Errorf("decoding Points: %w", err)
+	}
+	*p = q
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. Each edge's U is
+// encoded relative to the previous edge's U, and V relative to U.
+func (l EdgeList) MarshalBinary() ([]byte, error) {
+	b := appendHeader(nil, 'E')
+	b = binary.AppendUvarint(b, uint64(len(l)))
+	var prev I2
+	for _, e := range l {
+		b = appendDelta(b, e.U, prev)
+		b = appendDelta(b, e.V, e.U)
+		prev = e.U
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (l *EdgeList) UnmarshalBinary(b []byte) error {
+	d := newDecoder(b, 'E')
+	n := d.count()
+	m := make(EdgeList, 0, n)
+	var prev I2
+	for i := 0; i < n && d.err == nil; i++ {
+		u := d.delta(prev)
+		m = append(m, Edge{u, d.delta(u)})
+		prev = u
+	}
+	if err := d.finish(); err != nil {
+		return fmt.Errorf("decoding EdgeList: %w", err)
+	}
+	*l = m
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The vertices are
+// sorted and delta-encoded, then each vertex's neighbours are encoded as
+// (sorted, delta-encoded) indexes into the vertex list. The encoding of a
+// graph is deterministic.
+func (g *Graph) MarshalBinary() ([]byte, error) {
+	vs := make([]I2, 0, len(g.V))
+	for v, y := range g.V {
+		if y {
+			vs = append(vs, v)
+		}
+	}
+	sort.Slice(vs, func(i, j int) bool { return lexLess(vs[i], vs[j]) })
+	index := make(map[I2]int, len(vs))
+	for i, v := range vs {
+		index[v] = i
+	}
+
+	for u, l := range g.E {
+		if _, ok := index[u]; !ok && len(l) > 0 {
+			return nil, fmt.Errorf("encoding Graph: edges start at %v, which is not in V", u)
+		}
+	}
+
+	b := appendHeader(nil, 'G')
+	b = binary.AppendUvarint(b, uint64(len(vs)))
+	var prev I2
+	for _, v := range vs {
+		b = appendDelta(b, v, prev)
+		prev = v
+	}
+	var adj []int
+	for _, u := range vs {
+		adj = adj[:0]
+		for v, y := range g.E[u] {
+			if !y {
+				continue
+			}
+			i, ok := index[v]
+			if !ok {
+				return nil, fmt.Errorf("encoding Graph: edge %v-%v ends at a vertex not in V", u, v)
+			}
+			adj = append(adj, i)
+		}
+		sort.Ints(adj)
+		b = binary.AppendUvarint(b, uint64(len(adj)))
+		last := 0
+		for _, i := range adj {
+			b = binary.AppendUvarint(b, uint64(i-last))
+			last = i
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (g *Graph) UnmarshalBinary(b []byte) error {
+	d := newDecoder(b, 'G')
+	n := d.count()
+	vs := make([]I2, 0, n)
+	var prev I2
+	for i := 0; i < n && d.err == nil; i++ {
+		prev = d.delta(prev)
+		vs = append(vs, prev)
+	}
+	h := &Graph{V: make(VertexSet, len(vs)), E: make(map[I2]VertexSet)}
+	for _, v := range vs {
+		h.V[v] = true
+	}
+	for _, u := range vs {
+		m := d.count()
+		i := 0
+		for j := 0; j < m && d.err == nil; j++ {
+			// Check the delta before adding it, so that i can't overflow.
+			di := d.uvarint()
+			if di > len(vs)-1-i {
+				d.err = ErrCorrupt
+			}
+			if d.err != nil {
+				break
+			}
+			i += di
+			h.AddEdge(u, vs[i])
+		}
+	}
+	if err := d.finish(); err != nil {
+		return fmt.Errorf("decoding Graph: %w", err)
+	}
+	*g = *h
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func testGraph() *Graph {
+	g := NewGraph()
+	g.AddEdge(I2{0, 0}, I2{10, 0})
+	g.AddEdge(I2{10, 0}, I2{10, 10})
+	g.AddEdge(I2{10, 10}, I2{0, 0})
+	g.AddEdge(I2{0, 0}, I2{-5, 1000000})
+	return g
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	tests := []struct {
+		in  encoding.BinaryMarshaler
+		out encoding.BinaryUnmarshaler
+	}{
+		{Points{{0, 0}, {1, 1}, {-1000000, 5}, {1 << 30, -1 << 30}}, new(Points)},
+		{Points{}, new(Points)},
+		{EdgeList{{I2{1, 2}, I2{3, 4}}, {I2{-1, -2}, I2{-3, 40000}}}, new(EdgeList)},
+		{testGraph(), new(Graph)},
+	}
+	for i, test := range tests {
+		b, err := test.in.MarshalBinary()
+		if err != nil {
+			t.Fatalf("test #%d: MarshalBinary: %v", i, err)
+		}
+		if err := test.out.UnmarshalBinary(b); err != nil {
+			t.Fatalf("test #%d: UnmarshalBinary: %v", i, err)
+		}
+		got := reflect.ValueOf(test.out).Elem().Interface()
+		want := reflect.Indirect(reflect.ValueOf(test.in)).Interface()
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("test #%d: round trip got %v, want %v", i, got, want)
+		}
+		// Every proper prefix is truncated, so must be rejected.
+		for n := 0; n < len(b); n++ {
+			if err := test.out.UnmarshalBinary(b[:n]); err == nil {
+				t.Errorf("test #%d: UnmarshalBinary(b[:%d]) = nil, want error", i, n)
+			}
+		}
+	}
+}
+
+func TestCodecDeterministic(t *testing.T) {
+	b1, _ := testGraph().MarshalBinary()
+	b2, _ := testGraph().MarshalBinary()
+	if !reflect.DeepEqual(b1, b2) {
+		t.Errorf("Graph encodings differ: %x != %x", b1, b2)
+	}
+}
+
+func TestCodecCorrupt(t *testing.T) {
+	b, _ := testGraph().MarshalBinary()
+	b[2] = 99
+	if err := new(Graph).UnmarshalBinary(b); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("UnmarshalBinary(bad version) = %v, want ErrUnsupportedVersion", err)
+	}
+	if err := new(EdgeList).UnmarshalBinary([]byte{'v', 'P', 1, 0}); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("UnmarshalBinary(wrong type) = %v, want ErrCorrupt", err)
+	}
+	if err := new(Points).UnmarshalBinary([]byte{'v', 'P', 1, 0xff, 0xff, 0xff, 0xff, 0x0f}); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("UnmarshalBinary(huge count) = %v, want ErrCorrupt", err)
+	}
+	// A neighbour index delta large enough to overflow.
+	c := []byte{'v', 'G', 1, 2, 0, 0, 0, 0, 2, 1}
+	c = binary.AppendUvarint(c, math.MaxInt64)
+	c = append(c, 0)
+	if err := new(Graph).UnmarshalBinary(c); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("UnmarshalBinary(overflowing delta) = %v, want ErrCorrupt", err)
+	}
+	// Random corruption must never panic.
+	r := rand.New(rand.NewSource(1))
+	b, _ = testGraph().MarshalBinary()
+	for i := 0; i < 1000; i++ {
+		c := append([]byte(nil), b...)
+		c[3+r.Intn(len(c)-3)] = byte(r.Intn(256))
+		new(Graph).UnmarshalBinary(c)
+	}
+}
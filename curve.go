@@ -0,0 +1,163 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+// This file implements the Morton (Z-order) and Hilbert space-filling
+// curves over a 2^32 x 2^32 grid. Coordinates must be in the int32 range;
+// they are offset by 2^31 before encoding, so negative coordinates work and
+// the order of the curve is the same everywhere.
+
+import "sort"
+
+const curveOffset = 1 << 31
+
+func curveCoords(v I2) (x, y uint32) {
+	return uint32(int64(v.X) + curveOffset), uint32(int64(v.Y) + curveOffset)
+}
+
+func fromCurveCoords(x, y uint32) I2 {
+	return I2{int(int64(x) - curveOffset), int(int64(y) - curveOffset)}
+}
+
+// spread inserts a zero bit above each bit of x.
+func spread(x uint32) uint64 {
+	v := uint64(x)
+	v = (v | v<<16) & 0x0000ffff0000ffff
+	v = (v | v<<8) & 0x00ff00ff00ff00ff
+	v = (v | v<<4) & 0x0f0f0f0f0f0f0f0f
+	v = (v | v<<2) & 0x3333333333333333
+	v = (v | v<<1) & 0x5555555555555555
+	return v
+}
+
+// compact is the inverse of spread: it takes every even bit of v.
+func compact(v uint64) uint32 {
+	v &= 0x5555555555555555
+	v = (v | v>>1) & 0x3333333333333333
+	v = (v | v>>2) & 0x0f0f0f0f0f0f0f0f
+	v = (v | v>>4) & 0x00ff00ff00ff00ff
+	v = (v | v>>8) & 0x0000ffff0000ffff
+	v = (v | v>>16) & 0x00000000ffffffff
+	return uint32(v)
+}
+
+// MortonEncode returns the position of v along the Morton (Z-order) curve.
+// The bits of X are in the even bit positions, and Y in the odd positions.
+func MortonEncode(v I2) uint64 {
+	x, y := curveCoords(v)
+	return spread(x) | spread(y)<<1
+}
+
+// MortonDecode is the inverse of MortonEncode.
+func MortonDecode(d uint64) I2 {
+	return fromCurveCoords(compact(d), compact(d>>1))
+}
+
+// HilbertEncode returns the position of v along the Hilbert curve.
+func HilbertEncode(v I2) uint64 {
+	x, y := curveCoords(v)
+	var d uint64
+	for s := uint32(1 << 31); s > 0; s >>= 1 {
+		var rx, ry uint32
+		if x&s != 0 {
+			rx = 1
+		}
+		if y&s != 0 {
+			ry = 1
+		}
+		d += uint64(s) * uint64(s) * uint64((3*rx)^ry)
+		// Rotate the quadrant so the lower bits follow the curve.
+		if ry == 0 {
+			if rx == 1 {
+				x, y = ^x, ^y
+			}
+			x, y = y, x
+		}
+	}
+	return d
+}
+
+// HilbertDecode is the inverse of HilbertEncode.
+func HilbertDecode(d uint64) I2 {
+	var x, y uint32
+	for s := uint64(1); s < 1<<32; s <<= 1 {
+		rx := uint32(1 & (d >> 1))
+		ry := uint32(1 & (d ^ uint64(rx)))
+		if ry == 0 {
+			if rx == 1 {
+				x, y = uint32(s-1)-x, uint32(s-1)-y
+			}
+			x, y = y, x
+		}
+		x += uint32(s) * rx
+		y += uint32(s) * ry
+		d >>= 2
+	}
+	return fromCurveCoords(x, y)
+}
+
+// CurveRange is an inclusive range [Lo, Hi] of positions along a curve.
+type CurveRange struct{ Lo, Hi uint64 }
+
+// MortonRanges returns the smallest list of ranges of Morton curve
+// positions that contain exactly the points in r, in increasing order.
+func MortonRanges(r Rect) []CurveRange { return curveRanges(r, MortonEncode) }
+
+// HilbertRanges returns the smallest list of ranges of Hilbert curve
+// positions that contain exactly the points in r, in increasing order.
+func HilbertRanges(r Rect) []CurveRange { return curveRanges(r, HilbertEncode) }
+
+// curveRanges decomposes r into aligned quadtree squares. Each square is a
+// contiguous range of both the Morton and Hilbert curves, so the ranges
+// only need sorting and merging.
+func curveRanges(r Rect, encode func(I2) uint64) []CurveRange {
+	if r.DR.X <= r.UL.X || r.DR.Y <= r.UL.Y {
+		return nil
+	}
+	// Work with int64 offset coordinates, so the whole grid is [0, 2^32).
+	x0, y0 := int64(r.UL.X)+curveOffset, int64(r.UL.Y)+curveOffset
+	x1, y1 := int64(r.DR.X)+curveOffset, int64(r.DR.Y)+curveOffset
+	var out []CurveRange
+	var visit func(x, y int64, level uint)
+	visit = func(x, y int64, level uint) {
+		size := int64(1) << level
+		if x >= x1 || y >= y1 || x+size <= x0 || y+size <= y0 {
+			return
+		}
+		if x >= x0 && y >= y0 && x+size <= x1 && y+size <= y1 {
+			mask := uint64(1)<<(2*level) - 1
+			lo := encode(I2{int(x - curveOffset), int(y - curveOffset)}) &^ mask
+			out = append(out, CurveRange{lo, lo | mask})
+			return
+		}
+		half := size / 2
+		visit(x, y, level-1)
+		visit(x+half, y, level-1)
+		visit(x, y+half, level-1)
+		visit(x+half, y+half, level-1)
+	}
+	visit(0, 0, 32)
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Lo < out[j].Lo })
+	merged := out[:1]
+	for _, c := range out[1:] {
+		if last := &merged[len(merged)-1]; c.Lo == last.Hi+1 {
+			last.Hi = c.Hi
+		} else {
+			merged = append(merged, c)
+		}
+	}
+	return merged
+}
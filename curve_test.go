@@ -0,0 +1,108 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCurveRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	vs := []I2{{0, 0}, {-1, -1}, {math.MinInt32, math.MaxInt32}, {math.MaxInt32, math.MinInt32}}
+	for i := 0; i < 1000; i++ {
+		vs = append(vs, I2{int(r.Int31()) - r.Intn(1<<30)*2, int(r.Int31()) - r.Intn(1<<30)*2})
+	}
+	for _, v := range vs {
+		if got := MortonDecode(MortonEncode(v)); got != v {
+			t.Errorf("MortonDecode(MortonEncode(%v)) = %v", v, got)
+		}
+		if got := HilbertDecode(HilbertEncode(v)); got != v {
+			t.Errorf("HilbertDecode(HilbertEncode(%v)) = %v", v, got)
+		}
+	}
+}
+
+func TestMortonOrder(t *testing.T) {
+	// Z-order within a 2x2 block: (0,0), (1,0), (0,1), (1,1).
+	base := MortonEncode(I2{0, 0})
+	for i, v := range []I2{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+		if got, want := MortonEncode(v)-base, uint64(i); got != want {
+			t.Errorf("MortonEncode(%v) - base = %d, want %d", v, got, want)
+		}
+	}
+}
+
+func TestHilbertAdjacent(t *testing.T) {
+	// Consecutive Hilbert positions are always neighbouring cells.
+	start := HilbertEncode(I2{-5, 7})
+	prev := HilbertDecode(start)
+	for d := start + 1; d < start+10000; d++ {
+		v := HilbertDecode(d)
+		if w := v.Sub(prev); Abs(w.X)+Abs(w.Y) != 1 {
+			t.Fatalf("HilbertDecode(%d) = %v is not adjacent to %v", d, v, prev)
+		}
+		prev = v
+	}
+}
+
+func TestCurveRanges(t *testing.T) {
+	rects := []Rect{
+		NewRect(0, 0, 4, 4),
+		NewRect(-3, -2, 5, 6),
+		NewRect(7, 1, 8, 20),
+		NewRect(-100, 50, -37, 61),
+	}
+	for _, curve := range []struct {
+		name   string
+		encode func(I2) uint64
+		ranges func(Rect) []CurveRange
+	}{
+		{"Morton", MortonEncode, MortonRanges},
+		{"Hilbert", HilbertEncode, HilbertRanges},
+	} {
+		for _, r := range rects {
+			rs := curve.ranges(r)
+			var total uint64
+			for i, c := range rs {
+				total += c.Hi - c.Lo + 1
+				if i > 0 && c.Lo <= rs[i-1].Hi+1 {
+					t.Errorf("%sRanges(%v): ranges %v and %v overlap or touch", curve.name, r, rs[i-1], c)
+				}
+			}
+			if want := uint64(r.Size().Area()); total != want {
+				t.Errorf("%sRanges(%v) cover %d positions, want %d", curve.name, r, total, want)
+			}
+			for _, p := range RectRange(r.UL, r.DR) {
+				d, found := curve.encode(p), false
+				for _, c := range rs {
+					if c.Lo <= d && d <= c.Hi {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("%sRanges(%v) does not contain %v", curve.name, r, p)
+				}
+			}
+		}
+		if got := curve.ranges(NewRect(0, 0, 4, 4)); curve.name == "Morton" && len(got) != 1 {
+			t.Errorf("MortonRanges of aligned square = %v, want 1 range", got)
+		}
+		if got := curve.ranges(NewRect(3, 3, 3, 10)); got != nil {
+			t.Errorf("%sRanges(empty) = %v, want nil", curve.name, got)
+		}
+	}
+}
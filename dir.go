@@ -14,7 +14,9 @@
 
 package vec
 
-// One of the four cardinal directions.
+// One of the four cardinal directions, or one of the four diagonal
+// directions. As with screen coordinates, Up is towards -Y and Down is
+// towards +Y.
 type Direction int
 
 // Direction values.
@@ -23,4 +25,92 @@ const (
 	Right
 	Up
 	Down
+	UpLeft
+	UpRight
+	DownLeft
+	DownRight
 )
+
+// Dirs4 are the four cardinal directions, in clockwise order.
+var Dirs4 = [4]Direction{Up, Right, Down, Left}
+
+// Dirs8 are all eight directions, in clockwise order.
+var Dirs8 = [8]Direction{Up, UpRight, Right, DownRight, Down, DownLeft, Left, UpLeft}
+
+// dirIndex is the position of each direction in Dirs8.
+var dirIndex = [...]int{
+	Left:      6,
+	Right:     2,
+	Up:        0,
+	Down:      4,
+	UpLeft:    7,
+	UpRight:   1,
+	DownLeft:  5,
+	DownRight: 3,
+}
+
+var dirVecs = [...]I2{
+	Left:      {-1, 0},
+	Right:     {1, 0},
+	Up:        {0, -1},
+	Down:      {0, 1},
+	UpLeft:    {-1, -1},
+	UpRight:   {1, -1},
+	DownLeft:  {-1, 1},
+	DownRight: {1, 1},
+}
+
+// Valid reports whether d is one of the eight directions.
+func (d Direction) Valid() bool { return d >= 0 && int(d) < len(dirVecs) }
+
+// Diagonal reports whether d is one of the four diagonal directions.
+func (d Direction) Diagonal() bool { return d >= UpLeft && d <= DownRight }
+
+// Vec returns the offset to the neighbouring grid cell in direction d, e.g.
+// Up.Vec() is (0,-1). It returns (0,0) if d is not valid.
+func (d Direction) Vec() I2 {
+	if !d.Valid() {
+		return I2{}
+	}
+	return dirVecs[d]
+}
+
+// diagonal returns the diagonal direction with the given signs.
+func diagonal(left, up bool) Direction {
+	switch {
+	case left && up:
+		return UpLeft
+	case up:
+		return UpRight
+	case left:
+		return DownLeft
+	default:
+		return DownRight
+	}
+}
+
+// rotate returns the direction n eighth-turns clockwise from d.
+func (d Direction) rotate(n int) Direction {
+	if !d.Valid() {
+		return d
+	}
+	return Dirs8[(dirIndex[d]+n)&7]
+}
+
+// Opposite returns the direction opposite d, e.g. Up.Opposite() is Down.
+func (d Direction) Opposite() Direction { return d.rotate(4) }
+
+// RotateCW returns d rotated a quarter turn clockwise, e.g. Up.RotateCW() is Right.
+func (d Direction) RotateCW() Direction { return d.rotate(2) }
+
+// RotateCCW returns d rotated a quarter turn counterclockwise, e.g.
+// Up.RotateCCW() is Left.
+func (d Direction) RotateCCW() Direction { return d.rotate(-2) }
+
+// RotateCW45 returns d rotated an eighth turn clockwise, e.g.
+// Up.RotateCW45() is UpRight.
+func (d Direction) RotateCW45() Direction { return d.rotate(1) }
+
+// RotateCCW45 returns d rotated an eighth turn counterclockwise, e.g.
+// Up.RotateCCW45() is UpLeft.
+func (d Direction) RotateCCW45() Direction { return d.rotate(-1) }
@@ -0,0 +1,93 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"math"
+	"math/bits"
+	"testing"
+)
+
+func TestDirectionMethods(t *testing.T) {
+	for i, d := range Dirs8 {
+		if got, want := d.RotateCW45(), Dirs8[(i+1)%8]; got != want {
+			t.Errorf("%v.RotateCW45() = %v, want %v", d, got, want)
+		}
+		if got, want := d.RotateCW().RotateCCW(), d; got != want {
+			t.Errorf("%v.RotateCW().RotateCCW() = %v, want %v", d, got, want)
+		}
+		if got, want := d.Opposite().Vec(), d.Vec().Mul(-1); got != want {
+			t.Errorf("%v.Opposite().Vec() = %v, want %v", d, got, want)
+		}
+		if got := d.Vec().Dir8(); got != d {
+			t.Errorf("%v.Vec().Dir8() = %v", d, got)
+		}
+		if got := d.Vec().F2().Dir8(); got != d {
+			t.Errorf("%v.Vec().F2().Dir8() = %v", d, got)
+		}
+	}
+	for _, d := range Dirs4 {
+		if d.Diagonal() {
+			t.Errorf("%v.Diagonal() = true", d)
+		}
+		if got := d.Vec().Dir(); got != d {
+			t.Errorf("%v.Vec().Dir() = %v", d, got)
+		}
+	}
+	if got, want := Up.RotateCW(), Right; got != want {
+		t.Errorf("Up.RotateCW() = %v, want %v", got, want)
+	}
+	if got, want := UpLeft.RotateCCW(), DownLeft; got != want {
+		t.Errorf("UpLeft.RotateCCW() = %v, want %v", got, want)
+	}
+}
+
+func TestDir8(t *testing.T) {
+	tests := []struct {
+		v    I2
+		want Direction
+	}{
+		{I2{0, 0}, Right},
+		{I2{10, 4}, Right},     // 21.8 degrees
+		{I2{10, 5}, DownRight}, // 26.6 degrees
+		{I2{-4, -10}, Up},      // 21.8 degrees from the Y axis
+		{I2{-5, -11}, UpLeft},
+		{I2{-7, 2}, Left},
+	}
+	if bits.UintSize == 64 {
+		// Components large enough to overflow int64 squares.
+		big := int64(1) << 40
+		tests = append(tests, []struct {
+			v    I2
+			want Direction
+		}{
+			{I2{int(big), int(big + 1)}, DownRight},
+			{I2{int(big), int(big) / 2}, DownRight},
+			{I2{int(big), int(big) / 3}, Right},
+			{I2{math.MinInt, 0}, Left},
+			{I2{math.MinInt, math.MinInt}, UpLeft},
+			{I2{math.MinInt, math.MaxInt}, DownLeft},
+			{I2{math.MaxInt, math.MinInt / 3}, Right},
+		}...)
+	}
+	for _, test := range tests {
+		if got := test.v.Dir8(); got != test.want {
+			t.Errorf("%v.Dir8() = %v, want %v", test.v, got, test.want)
+		}
+		if got := test.v.F2().Dir8(); got != test.want {
+			t.Errorf("%v.F2().Dir8() = %v, want %v", test.v, got, test.want)
+		}
+	}
+}
@@ -19,4 +19,6 @@
 // The components of F2 are float64, and the components of I2 are int.
 // Functions for I2 use int operations and avoid division where possible,
 // though sometimes it is necessary to cast into int64 to avoid overflow.
+// The generic type V2 has the combined methods of both, for any integer or
+// floating-point component type.
 package vec
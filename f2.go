@@ -17,7 +17,9 @@ package vec
 import "math"
 
 // Epsilon is a small quantity used for floating point comparisons.
-// TODO: do it a better way.
+// LineIntersect and SegmentIntersect no longer use it; they use the
+// exact predicate Orient2D instead. It is unused, and kept only for
+// compatibility.
 const Epsilon = 0.0000000001
 
 // F2 is a tuple of float64 numbers, (X,Y).
@@ -49,6 +51,21 @@ func (v F2) Mul(k float64) F2 { return F2{v.X * k, v.Y * k} }
 // Div returns the componentwise division by k.
 func (v F2) Div(k float64) F2 { return F2{v.X / k, v.Y / k} }
 
+// EMul returns the element-wise product of v and w.
+func (v F2) EMul(w F2) F2 { return F2{v.X * w.X, v.Y * w.Y} }
+
+// EDiv returns the element-wise quotient of v and w.
+func (v F2) EDiv(w F2) F2 { return F2{v.X / w.X, v.Y / w.Y} }
+
+// Sgn returns a "unit-ish" vector (each component is normalised).
+func (v F2) Sgn() F2 { return F2{sgn(v.X), sgn(v.Y)} }
+
+// ClampLo returns v, but with components clamped below by components of e.
+func (v F2) ClampLo(e F2) F2 { return F2{math.Max(v.X, e.X), math.Max(v.Y, e.Y)} }
+
+// ClampHi returns v, but with components clamped above by components of e.
+func (v F2) ClampHi(e F2) F2 { return F2{math.Min(v.X, e.X), math.Min(v.Y, e.Y)} }
+
 // Dot returns the dot product, v dot w.
 func (v F2) Dot(w F2) float64 { return v.X*w.X + v.Y*w.Y }
 
@@ -74,6 +91,9 @@ func (v F2) Rot(t float64) F2 { return v.Cmul(Unit(t)) }
 // RotAbout rotates the vector by the angle t around the vector b.
 func (v F2) RotAbout(t float64, b F2) F2 { return v.Sub(b).Rot(t).Add(b) }
 
+// Swap switches x and y components.
+func (v F2) Swap() F2 { return F2{v.Y, v.X} }
+
 // Dir returns the general direction of v (Up, Down, Left, Right).
 func (v F2) Dir() Direction {
 	switch {
@@ -88,39 +108,63 @@ func (v F2) Dir() Direction {
 	}
 }
 
+// Dir8 returns the nearest of the eight directions to v. The zero vector
+// returns Right.
+func (v F2) Dir8() Direction {
+	ax, ay := math.Abs(v.X), math.Abs(v.Y)
+	switch {
+	case ay <= (math.Sqrt2-1)*ax:
+		if v.X < 0 {
+			return Left
+		}
+		return Right
+	case ax < (math.Sqrt2-1)*ay:
+		if v.Y < 0 {
+			return Up
+		}
+		return Down
+	}
+	return diagonal(v.X < 0, v.Y < 0)
+}
+
 // InRect tests if v is in the rectangle with topleft corner (x0, y0) and bottomright corner (x1, y1).
+// It is NewFRect(x0, y0, x1, y1).ContainsWith(v, Closed); note that
+// FRect.Contains excludes the bottom and right edges.
 func (v F2) InRect(x0, y0, x1, y1 float64) bool {
-	return v.X >= x0 && v.X <= x1 && v.Y >= y0 && v.Y <= y1
+	return NewFRect(x0, y0, x1, y1).ContainsWith(v, Closed)
 }
 
 // LineIntersect finds the intersection of the lines (infinite) through p,q and a,b,
-// or returns false if they are parallel.
+// or returns false if they are exactly parallel.
 func LineIntersect(p, q, a, b F2) (F2, bool) {
-	dx1, dx2, dy1, dy2 := p.X-q.X, a.X-b.X, p.Y-q.Y, a.Y-b.Y
-	det := dx2*dy1 - dx1*dy2
-	if -Epsilon < det && det < Epsilon {
+	det := cross(p, q, a, b)
+	if det == 0 {
 		return F2{}, false
 	}
-	pq := (q.X*p.Y - p.X*q.Y)
-	ab := (b.X*a.Y - a.X*b.Y)
-	return F2{pq*dx2 - ab*dx1, pq*dy2 - ab*dy1}.Div(det), true
+	return q.Sub(p).Mul(Orient2D(a, b, p) / det).Add(p), true
 }
 
 // SegmentIntersect tests for the intersection of the line segments p-q, a-b;
 // if there is an intersection it returns how far along a-b the
-// intersection occurs.
+// intersection occurs. The start of each segment is included, but not the
+// end. Collinear segments are not considered to intersect. Whether the
+// segments intersect is determined exactly, using Orient2D.
 func SegmentIntersect(p, q, a, b F2) (float64, bool) {
-	qmpn, bma := q.Sub(p).Normal(), b.Sub(a)
-	det := bma.Dot(qmpn)
-	if -Epsilon < det && det < Epsilon { // Singularish
+	// halfOpen reports whether the segment from an endpoint with orientation
+	// s to one with orientation e crosses zero, including s but not e.
+	halfOpen := func(s, e float64) bool {
+		return (s == 0 && e != 0) || (s < 0 && e > 0) || (s > 0 && e < 0)
+	}
+	if !halfOpen(Orient2D(a, b, p), Orient2D(a, b, q)) {
 		return 0, false
 	}
-	// Is our result (t,t') is within bounds?
-	pma := p.Sub(a)
-	t := pma.Dot(bma.Normal()) / det
-	if !(0 <= t && t < 1) {
+	oa, ob := Orient2D(p, q, a), Orient2D(p, q, b)
+	if !halfOpen(oa, ob) {
 		return 0, false
 	}
-	t = pma.Dot(qmpn) / det
-	return t, 0 <= t && t < 1
+	t := oa / (oa - ob)
+	if t >= 1 {
+		t = math.Nextafter(1, 0)
+	}
+	return t, true
 }
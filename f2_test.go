@@ -55,6 +55,11 @@ func TestSegmentIntersect(t *testing.T) {
 		{F2{-1, 0}, F2{1, 0}, F2{-1, -1}, F2{1, -1}, false, 0.0},                       // Parallel
 		{F2{-1, 0}, F2{1, 0}, F2{-1, 0.0000001}, F2{1, 0.0000001}, false, 0.0},         // Parallel
 		{F2{-1, -1}, F2{1, 1}, F2{0, 3}, F2{3, 0}, false, 0.0},                         // a-b beyond p-q.
+		{F2{0, 0}, F2{1e-150, 1e-150}, F2{0, 1e-150}, F2{1e-150, 0}, true, 0.5},        // Tiny
+		{F2{0, 0}, F2{1e-150, 1e-150}, F2{1e-150, 0}, F2{1e-150, 1e-150}, false, 0.0},  // Tiny, touching end
+		{F2{0, 0}, F2{1e100, 1e100}, F2{0, 1e100}, F2{1e100, 0}, true, 0.5},            // Huge
+		{F2{0, 0}, F2{3e100, 3e100}, F2{1e100, 1e100}, F2{2e100, 2e100}, false, 0.0},   // Huge, collinear
+		{F2{0.1, 0.1}, F2{0.7, 0.7}, F2{0.3, 0.3}, F2{0.3, 1}, true, 0.0},              // Touching start
 	}
 
 	for i, test := range tests {
@@ -0,0 +1,108 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import "math"
+
+// F3 is a triple of float64 numbers, (X,Y,Z).
+type F3 struct{ X, Y, Z float64 }
+
+// NewF3 is a convenience function for creating an F3.
+func NewF3(x, y, z float64) F3 { return F3{x, y, z} }
+
+// F3 extends v into 3D with the given Z component.
+func (v F2) F3(z float64) F3 { return F3{v.X, v.Y, z} }
+
+// C returns the components of v (X,Y,Z).
+func (v F3) C() (float64, float64, float64) { return v.X, v.Y, v.Z }
+
+// XY projects v onto the XY plane by dropping the Z component.
+func (v F3) XY() F2 { return F2{v.X, v.Y} }
+
+// I3 rounds this F3 to an I3, which will generally result in loss of precision.
+func (v F3) I3() I3 {
+	return I3{int(math.Round(v.X)), int(math.Round(v.Y)), int(math.Round(v.Z))}
+}
+
+// Add returns v + w.
+func (v F3) Add(w F3) F3 { return F3{v.X + w.X, v.Y + w.Y, v.Z + w.Z} }
+
+// Sub returns v - w.
+func (v F3) Sub(w F3) F3 { return F3{v.X - w.X, v.Y - w.Y, v.Z - w.Z} }
+
+// Mul returns the scalar product k * v.
+func (v F3) Mul(k float64) F3 { return F3{v.X * k, v.Y * k, v.Z * k} }
+
+// Div returns the componentwise division by k.
+func (v F3) Div(k float64) F3 { return F3{v.X / k, v.Y / k, v.Z / k} }
+
+// EMul returns the element-wise product of v and w.
+func (v F3) EMul(w F3) F3 { return F3{v.X * w.X, v.Y * w.Y, v.Z * w.Z} }
+
+// EDiv returns the element-wise quotient of v and w.
+func (v F3) EDiv(w F3) F3 { return F3{v.X / w.X, v.Y / w.Y, v.Z / w.Z} }
+
+// Sgn returns a "unit-ish" vector (each component is normalised).
+func (v F3) Sgn() F3 { return F3{sgn(v.X), sgn(v.Y), sgn(v.Z)} }
+
+// ClampLo returns v, but with components clamped below by components of e.
+func (v F3) ClampLo(e F3) F3 {
+	return F3{math.Max(v.X, e.X), math.Max(v.Y, e.Y), math.Max(v.Z, e.Z)}
+}
+
+// ClampHi returns v, but with components clamped above by components of e.
+func (v F3) ClampHi(e F3) F3 {
+	return F3{math.Min(v.X, e.X), math.Min(v.Y, e.Y), math.Min(v.Z, e.Z)}
+}
+
+// Dot returns the dot product, v dot w.
+func (v F3) Dot(w F3) float64 { return v.X*w.X + v.Y*w.Y + v.Z*w.Z }
+
+// Cross returns the cross product, v cross w.
+func (v F3) Cross(w F3) F3 {
+	return F3{v.Y*w.Z - v.Z*w.Y, v.Z*w.X - v.X*w.Z, v.X*w.Y - v.Y*w.X}
+}
+
+// Norm returns the length of v (the square root of v dot v).
+func (v F3) Norm() float64 { return math.Sqrt(v.Dot(v)) }
+
+// Unit returns the unit vector pointing in the same direction as v.
+func (v F3) Unit() F3 { return v.Mul(1 / v.Norm()) }
+
+// LineNearestPointF3 locates the point on the line passing through uv that
+// is closest to p, and returns the point and the square of the distance.
+func LineNearestPointF3(u, v, p F3) (F3, float64) {
+	pu := p.Sub(u)
+	if u == v {
+		return u, pu.Dot(pu)
+	}
+	vu := v.Sub(u)
+	q := u.Add(vu.Mul(vu.Dot(pu) / vu.Dot(vu)))
+	pq := p.Sub(q)
+	return q, pq.Dot(pq)
+}
+
+// SegmentNearestPointF3 locates the point on the line segment uv that is
+// closest to p, and returns the point and the square of the distance.
+func SegmentNearestPointF3(u, v, p F3) (F3, float64) {
+	pu, vu := p.Sub(u), v.Sub(u)
+	if pu.Dot(vu) <= 0 {
+		return u, pu.Dot(pu)
+	}
+	if vp := v.Sub(p); vp.Dot(vu) <= 0 {
+		return v, vp.Dot(vp)
+	}
+	return LineNearestPointF3(u, v, p)
+}
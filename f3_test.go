@@ -0,0 +1,75 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import "testing"
+
+func TestF3Methods(t *testing.T) {
+	v, w := F3{3, -4, 12}, F3{1, 2, -2}
+	if got, want := v.Add(w), (F3{4, -2, 10}); got != want {
+		t.Errorf("Add: got %v want %v", got, want)
+	}
+	if got, want := v.Sub(w), (F3{2, -6, 14}); got != want {
+		t.Errorf("Sub: got %v want %v", got, want)
+	}
+	if got, want := w.Mul(2), (F3{2, 4, -4}); got != want {
+		t.Errorf("Mul: got %v want %v", got, want)
+	}
+	if got, want := v.Dot(w), -29.0; got != want {
+		t.Errorf("Dot: got %f want %f", got, want)
+	}
+	if got, want := v.Norm(), 13.0; got != want {
+		t.Errorf("Norm: got %f want %f", got, want)
+	}
+	if got, want := w.Unit(), (F3{1.0 / 3, 2.0 / 3, -2.0 / 3}); got != want {
+		t.Errorf("Unit: got %v want %v", got, want)
+	}
+	if got, want := v.ClampLo(w).ClampHi(F3{2, 2, 2}), (F3{2, 2, 2}); got != want {
+		t.Errorf("Clamp: got %v want %v", got, want)
+	}
+	if got, want := (F3{1.4, -1.6, 2.5}).I3(), (I3{1, -2, 3}); got != want {
+		t.Errorf("I3: got %v want %v", got, want)
+	}
+	if got, want := v.XY(), (F2{3, -4}); got != want {
+		t.Errorf("XY: got %v want %v", got, want)
+	}
+}
+
+func TestSegmentNearestPointF3(t *testing.T) {
+	tests := []struct {
+		u, v, p F3
+		q       F3
+		d       float64
+	}{
+		{F3{0, 0, 0}, F3{0, 0, 0}, F3{1, 1, 1}, F3{0, 0, 0}, 3},      // u == v
+		{F3{0, 0, 0}, F3{4, 0, 0}, F3{1.5, 1, 1}, F3{1.5, 0, 0}, 2},  // p beside line
+		{F3{0, 0, 0}, F3{4, 0, 0}, F3{-1, 0, 1}, F3{0, 0, 0}, 2},     // p before u
+		{F3{0, 0, 0}, F3{4, 0, 0}, F3{6, 0, 0}, F3{4, 0, 0}, 4},      // p after v
+		{F3{-4, -4, -4}, F3{4, 4, 4}, F3{2, -1, -1}, F3{0, 0, 0}, 6}, // p off diagonal
+		{F3{0, 0, 0}, F3{0, 0, 10}, F3{0, 0, 7.5}, F3{0, 0, 7.5}, 0}, // along line
+	}
+	for i, test := range tests {
+		q, d := SegmentNearestPointF3(test.u, test.v, test.p)
+		if got, want := q, test.q; got != want {
+			t.Errorf("SegmentNearestPointF3 test #%d: got %v, want %v", i, got, want)
+		}
+		if got, want := d, test.d; got != want {
+			t.Errorf("SegmentNearestPointF3 test #%d: got %f, want %f", i, got, want)
+		}
+	}
+	if q, d := LineNearestPointF3(F3{0, 0, 0}, F3{4, 0, 0}, F3{6, 1, 0}); q != (F3{6, 0, 0}) || d != 1 {
+		t.Errorf("LineNearestPointF3: got %v, %f, want (6,0,0), 1", q, d)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import "math"
+
+// FRect is the half-open rectangle [UL.X, DR.X) * [UL.Y, DR.Y) with
+// float64 corners. It has the same semantics as Rect.
+type FRect struct {
+	UL, DR F2
+}
+
+// NewFRect is a convenience function for creating an FRect.
+func NewFRect(x0, y0, x1, y1 float64) FRect {
+	return FRect{UL: F2{x0, y0}, DR: F2{x1, y1}}
+}
+
+// FRect converts r to an FRect.
+func (r Rect) FRect() FRect { return FRect{UL: r.UL.F2(), DR: r.DR.F2()} }
+
+// C returns the coordinates of the corners of r.
+func (r FRect) C() (x0, y0, x1, y1 float64) {
+	return r.UL.X, r.UL.Y, r.DR.X, r.DR.Y
+}
+
+// Rect returns the smallest Rect containing r, by rounding UL down and DR
+// up.
+func (r FRect) Rect() Rect {
+	return Rect{
+		UL: I2{int(math.Floor(r.UL.X)), int(math.Floor(r.UL.Y))},
+		DR: I2{int(math.Ceil(r.DR.X)), int(math.Ceil(r.DR.Y))},
+	}
+}
+
+// InnerRect returns the largest Rect contained in r, by rounding UL up and
+// DR down.
+func (r FRect) InnerRect() Rect {
+	return Rect{
+		UL: I2{int(math.Ceil(r.UL.X)), int(math.Ceil(r.UL.Y))},
+		DR: I2{int(math.Floor(r.DR.X)), int(math.Floor(r.DR.Y))},
+	}
+}
+
+// Contains reports whether p is in r (see ContainsWith for closed
+// rectangles).
+func (r FRect) Contains(p F2) bool {
+	return r.ContainsWith(p, HalfOpen)
+}
+
+// Expand returns r grown by e in each direction.
+func (r FRect) Expand(e F2) FRect {
+	return FRect{UL: r.UL.Sub(e), DR: r.DR.Add(e)}
+}
+
+// Overlaps reports whether r and s have any points in common. Empty
+// rectangles overlap nothing.
+func (r FRect) Overlaps(s FRect) bool {
+	return !r.Intersect(s).Empty()
+}
+
+// Translate returns r moved by p.
+func (r FRect) Translate(p F2) FRect {
+	return FRect{UL: r.UL.Add(p), DR: r.DR.Add(p)}
+}
+
+// Size returns the width and height of r.
+func (r FRect) Size() F2 {
+	return r.DR.Sub(r.UL)
+}
+
+// Reposition returns r moved so that its UL corner is ul.
+func (r FRect) Reposition(ul F2) FRect {
+	return FRect{UL: ul, DR: ul.Add(r.Size())}
+}
+
+// Resize returns r with the same UL corner and the size sz.
+func (r FRect) Resize(sz F2) FRect {
+	return FRect{UL: r.UL, DR: r.UL.Add(sz)}
+}
+
+// Canon returns r with its corners swapped as needed, so that UL is the
+// componentwise minimum and DR the maximum.
+func (r FRect) Canon() FRect {
+	return FRect{UL: r.UL.ClampHi(r.DR), DR: r.DR.ClampLo(r.UL)}
+}
+
+// Empty reports whether r contains no points. Rectangles with NaN
+// coordinates are empty.
+func (r FRect) Empty() bool {
+	return r.X().Empty(HalfOpen) || r.Y().Empty(HalfOpen)
+}
+
+// Eq reports whether r and s contain the same points. All empty rectangles
+// are equal.
+func (r FRect) Eq(s FRect) bool {
+	return r == s || (r.Empty() && s.Empty())
+}
+
+// Area returns the area of r.
+func (r FRect) Area() float64 {
+	if r.Empty() {
+		return 0
+	}
+	sz := r.Size()
+	return sz.X * sz.Y
+}
+
+// Center returns the middle point of r.
+func (r FRect) Center() F2 {
+	return r.UL.Add(r.DR).Mul(0.5)
+}
+
+// In reports whether every point of r is in s. An empty r is in every s.
+func (r FRect) In(s FRect) bool {
+	return r.Empty() || (r.UL.X >= s.UL.X && r.UL.Y >= s.UL.Y && r.DR.X <= s.DR.X && r.DR.Y <= s.DR.Y)
+}
+
+// Intersect returns the largest rectangle contained in both r and s. If
+// they don't overlap, it returns the zero FRect.
+func (r FRect) Intersect(s FRect) FRect {
+	t := FRect{UL: r.UL.ClampLo(s.UL), DR: r.DR.ClampHi(s.DR)}
+	if t.Empty() {
+		return FRect{}
+	}
+	return t
+}
+
+// Union returns the smallest rectangle containing both r and s. Empty
+// rectangles are ignored.
+func (r FRect) Union(s FRect) FRect {
+	switch {
+	case r.Empty():
+		return s
+	case s.Empty():
+		return r
+	}
+	return FRect{UL: r.UL.ClampHi(s.UL), DR: r.DR.ClampLo(s.DR)}
+}
+
+// NearestPoint locates the point in the area covered by r (including its
+// edges) that is closest to p, and returns the point and the square of
+// the distance. r must not be empty.
+func (r FRect) NearestPoint(p F2) (F2, float64) {
+	q := p.ClampLo(r.UL).ClampHi(r.DR)
+	d := p.Sub(q)
+	return q, d.Dot(d)
+}
+
+// Separation returns the vector between r and s (see Rect.Separation).
+func (r FRect) Separation(s FRect) F2 {
+	return F2{gap(r.UL.X, r.DR.X, s.UL.X, s.DR.X), gap(r.UL.Y, r.DR.Y, s.UL.Y, s.DR.Y)}
+}
+
+// PushOut returns the smallest translation, along a single axis, that moves
+// s so that it no longer overlaps r (see Rect.PushOut).
+func (r FRect) PushOut(s FRect) F2 {
+	if !r.Overlaps(s) {
+		return F2{}
+	}
+	x := pushOut(r.UL.X, r.DR.X, s.UL.X, s.DR.X)
+	y := pushOut(r.UL.Y, r.DR.Y, s.UL.Y, s.DR.Y)
+	if math.Abs(x) <= math.Abs(y) {
+		return F2{x, 0}
+	}
+	return F2{0, y}
+}
+
+// fit returns the rectangle with the aspect ratio of size, centred on r,
+// whose width or height matches r: the smaller of the two if cover is
+// false, or the larger if cover is true.
+func (r FRect) fit(size F2, cover bool) FRect {
+	rs := r.Size()
+	k := min(rs.X/size.X, rs.Y/size.Y)
+	if cover {
+		k = max(rs.X/size.X, rs.Y/size.Y)
+	}
+	half := size.Mul(k / 2)
+	c := r.Center()
+	return FRect{UL: c.Sub(half), DR: c.Add(half)}
+}
+
+// Contain returns the largest rectangle with the same aspect ratio as size
+// that fits inside r, centred in r (letterboxing).
+func (r FRect) Contain(size F2) FRect { return r.fit(size, false) }
+
+// Cover returns the smallest rectangle with the same aspect ratio as size
+// that covers r, centred on r (cropping).
+func (r FRect) Cover(size F2) FRect { return r.fit(size, true) }
@@ -0,0 +1,129 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFRectConversions(t *testing.T) {
+	tests := []struct {
+		r            FRect
+		outer, inner Rect
+	}{
+		{NewFRect(0, 0, 4, 3), NewRect(0, 0, 4, 3), NewRect(0, 0, 4, 3)},
+		{NewFRect(0.5, -0.5, 3.5, 2.1), NewRect(0, -1, 4, 3), NewRect(1, 0, 3, 2)},
+		{NewFRect(-1.2, -2.8, -0.1, 0.9), NewRect(-2, -3, 0, 1), NewRect(-1, -2, -1, 0)},
+	}
+	for i, test := range tests {
+		if got := test.r.Rect(); got != test.outer {
+			t.Errorf("Rect test #%d: got %v, want %v", i, got, test.outer)
+		}
+		if got := test.r.InnerRect(); got != test.inner {
+			t.Errorf("InnerRect test #%d: got %v, want %v", i, got, test.inner)
+		}
+		if got := test.outer.FRect(); !test.r.In(got) {
+			t.Errorf("Rect test #%d: %v.FRect() = %v doesn't contain %v", i, test.outer, got, test.r)
+		}
+	}
+}
+
+func TestFRectAlgebra(t *testing.T) {
+	a, b := NewFRect(0, 0, 4, 3), NewFRect(2, 1.5, 6, 5)
+	tests := []struct {
+		r, s             FRect
+		intersect, union FRect
+		overlaps         bool
+	}{
+		{a, b, NewFRect(2, 1.5, 4, 3), NewFRect(0, 0, 6, 5), true},
+		{a, NewFRect(4, 0, 6, 3), FRect{}, NewFRect(0, 0, 6, 3), false},
+		{a, NewFRect(1, 1, 1, 2), FRect{}, a, false},
+		{NewFRect(math.NaN(), 0, 1, 1), a, FRect{}, a, false},
+	}
+	for i, test := range tests {
+		if got := test.r.Intersect(test.s); got != test.intersect {
+			t.Errorf("Intersect test #%d: got %v, want %v", i, got, test.intersect)
+		}
+		if got := test.r.Union(test.s); got != test.union {
+			t.Errorf("Union test #%d: got %v, want %v", i, got, test.union)
+		}
+		if got := test.r.Overlaps(test.s); got != test.overlaps {
+			t.Errorf("Overlaps test #%d: got %t, want %t", i, got, test.overlaps)
+		}
+	}
+
+	r := NewFRect(3, 4, 1, 2)
+	if got, want := r.Canon(), NewFRect(1, 2, 3, 4); got != want {
+		t.Errorf("Canon: got %v, want %v", got, want)
+	}
+	if !r.Empty() || r.Area() != 0 || !r.Eq(FRect{}) {
+		t.Errorf("%v: want empty, zero area, equal to FRect{}", r)
+	}
+	if got, want := a.Area(), 12.0; got != want {
+		t.Errorf("Area: got %v, want %v", got, want)
+	}
+	if got, want := a.Center(), (F2{2, 1.5}); got != want {
+		t.Errorf("Center: got %v, want %v", got, want)
+	}
+	if !a.Contains(F2{0, 0}) || a.Contains(F2{4, 1}) || a.Contains(F2{1, 3}) {
+		t.Errorf("Contains: want half-open semantics")
+	}
+	if got, want := a.Expand(F2{1, 0.5}).Translate(F2{1, 1}), NewFRect(0, 0.5, 6, 4.5); got != want {
+		t.Errorf("Expand/Translate: got %v, want %v", got, want)
+	}
+}
+
+func TestFRectFit(t *testing.T) {
+	screen := NewFRect(0, 0, 200, 100)
+	tests := []struct {
+		size           F2
+		contain, cover FRect
+	}{
+		{F2{4, 3}, NewFRect(100-200.0/3, 0, 100+200.0/3, 100), NewFRect(0, 50-75, 200, 50+75)},
+		{F2{1, 1}, NewFRect(50, 0, 150, 100), NewFRect(0, -50, 200, 150)},
+		{F2{20, 10}, screen, screen},
+		{F2{4, 1}, NewFRect(0, 25, 200, 75), NewFRect(-100, 0, 300, 100)},
+	}
+	for i, test := range tests {
+		got := screen.Contain(test.size)
+		if !near(got.UL, test.contain.UL) || !near(got.DR, test.contain.DR) {
+			t.Errorf("Contain test #%d: got %v, want %v", i, got, test.contain)
+		}
+		got = screen.Cover(test.size)
+		if !near(got.UL, test.cover.UL) || !near(got.DR, test.cover.DR) {
+			t.Errorf("Cover test #%d: got %v, want %v", i, got, test.cover)
+		}
+	}
+}
+
+func TestFRectNearestPoint(t *testing.T) {
+	r := NewFRect(0, 0, 4, 3)
+	tests := []struct {
+		p, want F2
+		dist2   float64
+	}{
+		{F2{1, 1}, F2{1, 1}, 0},
+		{F2{-2, 1.5}, F2{0, 1.5}, 4},
+		{F2{4.5, 1}, F2{4, 1}, 0.25},
+		{F2{7, 7}, F2{4, 3}, 25},
+	}
+	for i, test := range tests {
+		got, d := r.NearestPoint(test.p)
+		if got != test.want || d != test.dist2 {
+			t.Errorf("NearestPoint test #%d: got (%v, %v), want (%v, %v)", i, got, d, test.want, test.dist2)
+		}
+	}
+}
@@ -16,6 +16,7 @@ package vec
 
 import (
 	"errors"
+	"iter"
 	"math"
 )
 
@@ -103,6 +104,34 @@ func (g *Graph) Edges() (edges []Edge) {
 	return
 }
 
+// EdgesSeq returns an iterator over all the edges u-v, in no particular order.
+func (g *Graph) EdgesSeq() iter.Seq2[I2, I2] {
+	return func(yield func(I2, I2) bool) { g.AllEdges(yield) }
+}
+
+// Vertices returns an iterator over all the vertices, in no particular order.
+func (g *Graph) Vertices() iter.Seq[I2] {
+	return func(yield func(I2) bool) {
+		for v, y := range g.V {
+			if y && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors returns an iterator over the vertices v for which u-v is an
+// edge, in no particular order.
+func (g *Graph) Neighbors(u I2) iter.Seq[I2] {
+	return func(yield func(I2) bool) {
+		for v, y := range g.E[u] {
+			if y && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
 // NumEdges counts the number of edges.
 func (g *Graph) NumEdges() (n int) {
 	for _, l := range g.E {
@@ -129,6 +158,19 @@ func (g *Graph) FullyBlocks(start, end I2) bool {
 	})
 }
 
+// BlocksBy determines if the graph intersects the straight line segment
+// start-end, where only intersections for which blocked returns true count.
+// Only edges facing start, or collinear with it (so that CollinearOverlap
+// can be reported), are considered.
+func (g *Graph) BlocksBy(start, end I2, blocked func(Intersection) bool) bool {
+	return !g.AllEdges(func(u, v I2) bool {
+		if SignedArea2(start, u, v) < 0 {
+			return true
+		}
+		return !blocked(IntersectSegmentsI(u, v, start, end))
+	})
+}
+
 // NearestBlock returns the intersection with the graph nearest to start.
 func (g *Graph) NearestBlock(start, end I2) (I2, bool) {
 	found := false
@@ -0,0 +1,92 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestGraphIterators(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(I2{0, 0}, I2{1, 0})
+	g.AddEdge(I2{0, 0}, I2{0, 1})
+	g.AddEdge(I2{1, 0}, I2{1, 1})
+
+	var edges []Edge
+	for u, v := range g.EdgesSeq() {
+		edges = append(edges, Edge{u, v})
+	}
+	slices.SortFunc(edges, func(a, b Edge) int { return cmp.Or(cmpI2(a.U, b.U), cmpI2(a.V, b.V)) })
+	if want := []Edge{{I2{0, 0}, I2{1, 0}}, {I2{0, 0}, I2{0, 1}}, {I2{1, 0}, I2{1, 1}}}; !reflect.DeepEqual(edges, want) {
+		t.Errorf("EdgesSeq: got %v, want %v", edges, want)
+	}
+
+	verts := slices.SortedFunc(g.Vertices(), cmpI2)
+	if want := []I2{{0, 0}, {1, 0}, {0, 1}, {1, 1}}; !reflect.DeepEqual(verts, want) {
+		t.Errorf("Vertices: got %v, want %v", verts, want)
+	}
+
+	tests := []struct {
+		u    I2
+		want []I2
+	}{
+		{I2{0, 0}, []I2{{1, 0}, {0, 1}}},
+		{I2{1, 0}, []I2{{1, 1}}},
+		{I2{1, 1}, nil},
+	}
+	for i, test := range tests {
+		if got := slices.SortedFunc(g.Neighbors(test.u), cmpI2); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Neighbors(%v) test #%d: got %v, want %v", test.u, i, got, test.want)
+		}
+	}
+}
+
+func TestGraphBlocksBy(t *testing.T) {
+	touching := func(i Intersection) bool { return i.Kind != Disjoint }
+	crossing := func(i Intersection) bool { return i.Kind == Crossing }
+	overlap := func(i Intersection) bool { return i.Kind == CollinearOverlap }
+
+	tests := []struct {
+		u, v, start, end              I2
+		kind                          IntersectionKind
+		wantTouch, wantCross, wantOvl bool
+	}{
+		{I2{2, -1}, I2{2, 1}, I2{0, 0}, I2{4, 0}, Crossing, true, true, false},
+		{I2{2, -1}, I2{2, 1}, I2{0, 0}, I2{2, 0}, TouchingEndpoint, true, false, false},
+		{I2{2, -1}, I2{2, 1}, I2{0, 0}, I2{1, 0}, Disjoint, false, false, false},
+		{I2{2, 1}, I2{2, -1}, I2{0, 0}, I2{4, 0}, Crossing, false, false, false}, // facing away
+		{I2{1, 0}, I2{3, 0}, I2{0, 0}, I2{4, 0}, CollinearOverlap, true, false, true},
+		{I2{3, 0}, I2{1, 0}, I2{0, 0}, I2{4, 0}, CollinearOverlap, true, false, true},
+	}
+	for i, test := range tests {
+		if got := IntersectSegmentsI(test.u, test.v, test.start, test.end).Kind; got != test.kind {
+			t.Fatalf("BlocksBy test #%d: IntersectSegmentsI kind = %v, want %v", i, got, test.kind)
+		}
+		g := NewGraph()
+		g.AddEdge(test.u, test.v)
+		if got := g.BlocksBy(test.start, test.end, touching); got != test.wantTouch {
+			t.Errorf("BlocksBy(touching) test #%d: got %t, want %t", i, got, test.wantTouch)
+		}
+		if got := g.BlocksBy(test.start, test.end, crossing); got != test.wantCross {
+			t.Errorf("BlocksBy(crossing) test #%d: got %t, want %t", i, got, test.wantCross)
+		}
+		if got := g.BlocksBy(test.start, test.end, overlap); got != test.wantOvl {
+			t.Errorf("BlocksBy(overlap) test #%d: got %t, want %t", i, got, test.wantOvl)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+// Grid is a rectangular array of cells, one for each point in Bounds,
+// stored in row-major order. It was introduced together with GridImage,
+// which presents a Grid as an image.
+type Grid[T any] struct {
+	Bounds Rect
+	Data   []T
+}
+
+// NewGrid creates a Grid covering r, with every cell set to the zero value.
+func NewGrid[T any](r Rect) *Grid[T] {
+	return &Grid[T]{Bounds: r, Data: make([]T, r.Area())}
+}
+
+// index returns the index of p in Data, or -1 if p is out of bounds.
+func (g *Grid[T]) index(p I2) int {
+	if !g.Bounds.Contains(p) {
+		return -1
+	}
+	return (p.Y-g.Bounds.UL.Y)*(g.Bounds.DR.X-g.Bounds.UL.X) + p.X - g.Bounds.UL.X
+}
+
+// At returns the cell at p, or the zero value if p is out of bounds.
+func (g *Grid[T]) At(p I2) (v T) {
+	if i := g.index(p); i >= 0 {
+		v = g.Data[i]
+	}
+	return v
+}
+
+// Set sets the cell at p to v. It does nothing if p is out of bounds.
+func (g *Grid[T]) Set(p I2, v T) {
+	if i := g.index(p); i >= 0 {
+		g.Data[i] = v
+	}
+}
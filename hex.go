@@ -0,0 +1,319 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+// The hex grid types follow the conventions of Amit Patel's "Hexagonal
+// Grids" guide (https://www.redblobgames.com/grids/hexagons/).
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+)
+
+// Hex is a hexagon in axial coordinates (Q,R). The implied third cube
+// coordinate is S = -Q-R.
+type Hex struct{ Q, R int }
+
+// Cube is a hexagon in cube coordinates (Q,R,S), where Q+R+S = 0.
+type Cube struct{ Q, R, S int }
+
+// NewHex is a convenience function for creating a Hex.
+func NewHex(q, r int) Hex { return Hex{q, r} }
+
+// S returns the implied third cube coordinate, -Q-R.
+func (h Hex) S() int { return -h.Q - h.R }
+
+// Cube converts h to cube coordinates.
+func (h Hex) Cube() Cube { return Cube{h.Q, h.R, -h.Q - h.R} }
+
+// Hex converts c to axial coordinates.
+func (c Cube) Hex() Hex { return Hex{c.Q, c.R} }
+
+// Add returns h + g.
+func (h Hex) Add(g Hex) Hex { return Hex{h.Q + g.Q, h.R + g.R} }
+
+// Sub returns h - g.
+func (h Hex) Sub(g Hex) Hex { return Hex{h.Q - g.Q, h.R - g.R} }
+
+// Mul returns the scalar product k * h.
+func (h Hex) Mul(k int) Hex { return Hex{h.Q * k, h.R * k} }
+
+// HexDirs are the offsets to the six neighbours of a hex, in
+// counterclockwise order starting from +Q.
+var HexDirs = [6]Hex{{1, 0}, {1, -1}, {0, -1}, {-1, 0}, {-1, 1}, {0, 1}}
+
+// Neighbor returns the neighbour of h in direction HexDirs[d%6].
+func (h Hex) Neighbor(d int) Hex { return h.Add(HexDirs[((d%6)+6)%6]) }
+
+// Neighbors returns all six neighbours of h, in the order of HexDirs.
+func (h Hex) Neighbors() (n [6]Hex) {
+	for i, d := range HexDirs {
+		n[i] = h.Add(d)
+	}
+	return n
+}
+
+// Len returns the distance from the origin to h, in steps between neighbours.
+func (h Hex) Len() int { return (Abs(h.Q) + Abs(h.R) + Abs(h.Q+h.R)) / 2 }
+
+// HexDistance returns the number of steps between neighbours from a to b.
+func HexDistance(a, b Hex) int { return a.Sub(b).Len() }
+
+// HexRing returns the hexes at exactly the given distance from center, in
+// counterclockwise order.
+func HexRing(center Hex, radius int) []Hex {
+	if radius <= 0 {
+		return []Hex{center}
+	}
+	r := make([]Hex, 0, 6*radius)
+	h := center.Add(HexDirs[4].Mul(radius))
+	for _, d := range HexDirs {
+		for j := 0; j < radius; j++ {
+			r = append(r, h)
+			h = h.Add(d)
+		}
+	}
+	return r
+}
+
+// HexSpiral returns the hexes within the given distance of center, ordered
+// by distance from center (center first), then counterclockwise.
+func HexSpiral(center Hex, radius int) []Hex {
+	s := []Hex{center}
+	for k := 1; k <= radius; k++ {
+		s = append(s, HexRing(center, k)...)
+	}
+	return s
+}
+
+// roundDiv returns n/d rounded to the nearest integer (ties upwards), for d > 0.
+func roundDiv(n, d int) int { return divDown(2*n+d, 2*d) }
+
+// cubeRoundScaled rounds the cube coordinates (q,r,s)/d to the nearest hex,
+// where q+r+s = 0 and d > 0.
+func cubeRoundScaled(q, r, s, d int) Hex {
+	rq, rr, rs := roundDiv(q, d), roundDiv(r, d), roundDiv(s, d)
+	dq, dr, ds := Abs(rq*d-q), Abs(rr*d-r), Abs(rs*d-s)
+	switch {
+	case dq > dr && dq > ds:
+		rq = -rr - rs
+	case dr > ds:
+		rr = -rq - rs
+	}
+	return Hex{rq, rr}
+}
+
+// HexLine returns the hexes on the straight line from a to b, inclusive.
+// It uses only integer arithmetic, and lines exactly along hex edges are
+// always nudged to the same side.
+func HexLine(a, b Hex) []Hex {
+	n := HexDistance(a, b)
+	if n == 0 {
+		return []Hex{a}
+	}
+	ac, bc := a.Cube(), b.Cube()
+	line := make([]Hex, 0, n+1)
+	// Work in units of 1/(8n), and nudge the start by (1,2,-3) units so
+	// that points exactly between two hexes are rounded consistently.
+	d := 8 * n
+	for i := 0; i <= n; i++ {
+		q := 8*(ac.Q*n+(bc.Q-ac.Q)*i) + 1
+		r := 8*(ac.R*n+(bc.R-ac.R)*i) + 2
+		s := 8*(ac.S*n+(bc.S-ac.S)*i) - 3
+		line = append(line, cubeRoundScaled(q, r, s, d))
+	}
+	return line
+}
+
+// HexOrientation is whether hexagons have a pointy top or a flat top.
+type HexOrientation int
+
+// HexOrientation values.
+const (
+	Pointy = HexOrientation(iota)
+	Flat
+)
+
+// HexLayout describes how a hex grid maps to pixel positions.
+type HexLayout struct {
+	Orientation HexOrientation
+	Size        F2 // distance from centre to corner, in X and Y
+	Origin      F2 // pixel position of the centre of Hex{0, 0}
+}
+
+// ToPixel returns the pixel position of the centre of h.
+func (l HexLayout) ToPixel(h Hex) F2 {
+	q, r := float64(h.Q), float64(h.R)
+	var p F2
+	if l.Orientation == Flat {
+		p = F2{1.5 * q, math.Sqrt(3)/2*q + math.Sqrt(3)*r}
+	} else {
+		p = F2{math.Sqrt(3)*q + math.Sqrt(3)/2*r, 1.5 * r}
+	}
+	return p.EMul(l.Size).Add(l.Origin)
+}
+
+// FromPixel returns the hex containing the pixel position p.
+func (l HexLayout) FromPixel(p F2) Hex {
+	p = p.Sub(l.Origin).EDiv(l.Size)
+	var q, r float64
+	if l.Orientation == Flat {
+		q, r = 2.0/3*p.X, -1.0/3*p.X+math.Sqrt(3)/3*p.Y
+	} else {
+		q, r = math.Sqrt(3)/3*p.X-1.0/3*p.Y, 2.0/3*p.Y
+	}
+	s := -q - r
+	rq, rr, rs := math.Round(q), math.Round(r), math.Round(s)
+	dq, dr, ds := math.Abs(rq-q), math.Abs(rr-r), math.Abs(rs-s)
+	switch {
+	case dq > dr && dq > ds:
+		rq = -rr - rs
+	case dr > ds:
+		rr = -rq - rs
+	}
+	return Hex{int(rq), int(rr)}
+}
+
+// HexOffset is a convention for storing hexes in a rectangular array,
+// where every other row (or column) is shifted by half a hex.
+type HexOffset int
+
+// HexOffset values. OddR and EvenR are for Pointy layouts (rows are
+// shifted); OddQ and EvenQ are for Flat layouts (columns are shifted).
+const (
+	OddR = HexOffset(iota)
+	EvenR
+	OddQ
+	EvenQ
+)
+
+// Offset converts h to offset coordinates (column, row) as an I2.
+func (h Hex) Offset(o HexOffset) I2 {
+	switch o {
+	case EvenR:
+		return I2{h.Q + (h.R+(h.R&1))/2, h.R}
+	case OddQ:
+		return I2{h.Q, h.R + (h.Q-(h.Q&1))/2}
+	case EvenQ:
+		return I2{h.Q, h.R + (h.Q+(h.Q&1))/2}
+	default:
+		return I2{h.Q + (h.R-(h.R&1))/2, h.R}
+	}
+}
+
+// HexFromOffset converts offset coordinates (column, row) to a Hex.
+func HexFromOffset(v I2, o HexOffset) Hex {
+	switch o {
+	case EvenR:
+		return Hex{v.X - (v.Y+(v.Y&1))/2, v.Y}
+	case OddQ:
+		return Hex{v.X, v.Y - (v.X-(v.X&1))/2}
+	case EvenQ:
+		return Hex{v.X, v.Y - (v.X+(v.X&1))/2}
+	default:
+		return Hex{v.X - (v.Y-(v.Y&1))/2, v.Y}
+	}
+}
+
+// HexSet is a set of hexes.
+type HexSet map[Hex]bool
+
+// hexQueue is a priority queue for HexFindPath.
+type hexQueue []hexItem
+
+type hexItem struct {
+	h    Hex
+	f, g int
+}
+
+func (q hexQueue) Len() int { return len(q) }
+func (q hexQueue) Less(i, j int) bool {
+	if q[i].f != q[j].f {
+		return q[i].f < q[j].f
+	}
+	return q[i].g > q[j].g
+}
+func (q hexQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *hexQueue) Push(x any)   { *q = append(*q, x.(hexItem)) }
+func (q *hexQueue) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
+
+// HexFindPath finds a shortest path of neighbouring hexes from start to
+// end, moving only through hexes for which passable returns true.
+// The path will only use hexes within the given distance of start.
+// Like FindPath, the path excludes start and includes end.
+func HexFindPath(start, end Hex, radius int, passable func(Hex) bool) ([]Hex, error) {
+	if HexDistance(start, end) > radius || !passable(end) {
+		return nil, errors.New("no path")
+	}
+	prev := map[Hex]Hex{}
+	dist := map[Hex]int{start: 0}
+	q := &hexQueue{{h: start, f: HexDistance(start, end)}}
+	for q.Len() > 0 {
+		it := heap.Pop(q).(hexItem)
+		if it.g > dist[it.h] {
+			continue // stale
+		}
+		if it.h == end {
+			break
+		}
+		for _, n := range it.h.Neighbors() {
+			if HexDistance(start, n) > radius || !passable(n) {
+				continue
+			}
+			if d, ok := dist[n]; ok && d <= it.g+1 {
+				continue
+			}
+			dist[n] = it.g + 1
+			prev[n] = it.h
+			heap.Push(q, hexItem{h: n, g: it.g + 1, f: it.g + 1 + HexDistance(n, end)})
+		}
+	}
+	if _, ok := dist[end]; !ok {
+		return nil, errors.New("no path")
+	}
+	path := make([]Hex, dist[end])
+	for h, i := end, len(path)-1; i >= 0; h, i = prev[h], i-1 {
+		path[i] = h
+	}
+	return path, nil
+}
+
+// HexFOV returns the hexes within the given distance of center that are
+// visible from center, where a hex is visible if no hex strictly between
+// it and center (along HexLine) is opaque. Opaque hexes can themselves
+// be visible.
+func HexFOV(center Hex, radius int, opaque func(Hex) bool) HexSet {
+	vis := HexSet{center: true}
+	for _, h := range HexSpiral(center, radius)[1:] {
+		line := HexLine(center, h)
+		seen := true
+		for _, m := range line[1 : len(line)-1] {
+			if opaque(m) {
+				seen = false
+				break
+			}
+		}
+		if seen {
+			vis[h] = true
+		}
+	}
+	return vis
+}
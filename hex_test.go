@@ -0,0 +1,143 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHexDistance(t *testing.T) {
+	if got, want := HexDistance(Hex{0, 0}, Hex{3, -1}), 3; got != want {
+		t.Errorf("HexDistance = %d, want %d", got, want)
+	}
+	if got, want := HexDistance(Hex{-2, 5}, Hex{1, 1}), 4; got != want {
+		t.Errorf("HexDistance = %d, want %d", got, want)
+	}
+	for _, n := range (Hex{4, -7}).Neighbors() {
+		if got := HexDistance(Hex{4, -7}, n); got != 1 {
+			t.Errorf("HexDistance to neighbour %v = %d, want 1", n, got)
+		}
+	}
+}
+
+func TestHexRingSpiral(t *testing.T) {
+	c := Hex{2, -1}
+	for r := 0; r < 5; r++ {
+		ring := HexRing(c, r)
+		want := 6 * r
+		if r == 0 {
+			want = 1
+		}
+		if len(ring) != want {
+			t.Errorf("len(HexRing(%d)) = %d, want %d", r, len(ring), want)
+		}
+		seen := HexSet{}
+		for _, h := range ring {
+			if d := HexDistance(c, h); d != r {
+				t.Errorf("HexRing(%d) contains %v at distance %d", r, h, d)
+			}
+			seen[h] = true
+		}
+		if len(seen) != len(ring) {
+			t.Errorf("HexRing(%d) has duplicates", r)
+		}
+	}
+	if got, want := len(HexSpiral(c, 3)), 1+6+12+18; got != want {
+		t.Errorf("len(HexSpiral(3)) = %d, want %d", got, want)
+	}
+}
+
+func TestHexLine(t *testing.T) {
+	line := HexLine(Hex{0, 0}, Hex{4, -2})
+	if len(line) != 5 || line[0] != (Hex{0, 0}) || line[4] != (Hex{4, -2}) {
+		t.Fatalf("HexLine = %v", line)
+	}
+	for i := 1; i < len(line); i++ {
+		if HexDistance(line[i-1], line[i]) != 1 {
+			t.Errorf("HexLine steps from %v to %v", line[i-1], line[i])
+		}
+	}
+}
+
+func TestHexPixel(t *testing.T) {
+	for _, o := range []HexOrientation{Pointy, Flat} {
+		l := HexLayout{Orientation: o, Size: F2{10, 12}, Origin: F2{100, 50}}
+		for _, h := range HexSpiral(Hex{}, 4) {
+			if got := l.FromPixel(l.ToPixel(h)); got != h {
+				t.Errorf("orientation %d: FromPixel(ToPixel(%v)) = %v", o, h, got)
+			}
+			if got := l.FromPixel(l.ToPixel(h).Add(F2{3, -3})); got != h {
+				t.Errorf("orientation %d: FromPixel(ToPixel(%v)+(3,-3)) = %v", o, h, got)
+			}
+		}
+	}
+}
+
+func TestHexOffset(t *testing.T) {
+	for _, o := range []HexOffset{OddR, EvenR, OddQ, EvenQ} {
+		for _, h := range HexSpiral(Hex{-1, 3}, 4) {
+			if got := HexFromOffset(h.Offset(o), o); got != h {
+				t.Errorf("offset %d: HexFromOffset(%v.Offset()) = %v", o, h, got)
+			}
+		}
+	}
+	if got, want := (Hex{-1, 3}).Offset(OddR), (I2{0, 3}); got != want {
+		t.Errorf("Offset(OddR) = %v, want %v", got, want)
+	}
+}
+
+func TestHexFindPath(t *testing.T) {
+	// A wall from (1,-3) to (1,2) forces a detour.
+	wall := HexSet{}
+	for r := -3; r <= 2; r++ {
+		wall[Hex{1, r}] = true
+	}
+	passable := func(h Hex) bool { return !wall[h] }
+	path, err := HexFindPath(Hex{0, 0}, Hex{2, 0}, 10, passable)
+	if err != nil {
+		t.Fatalf("HexFindPath: %v", err)
+	}
+	if got, want := len(path), 7; got != want {
+		t.Errorf("len(path) = %d, want %d (path %v)", got, want, path)
+	}
+	prev := Hex{0, 0}
+	for _, h := range path {
+		if wall[h] || HexDistance(prev, h) != 1 {
+			t.Errorf("bad step %v -> %v", prev, h)
+		}
+		prev = h
+	}
+	if _, err := HexFindPath(Hex{0, 0}, Hex{2, 0}, 2, passable); err == nil {
+		t.Errorf("HexFindPath with small radius: got nil error")
+	}
+}
+
+func TestHexFOV(t *testing.T) {
+	opaque := func(h Hex) bool { return h == (Hex{1, 0}) }
+	vis := HexFOV(Hex{0, 0}, 3, opaque)
+	if !vis[Hex{1, 0}] {
+		t.Errorf("opaque hex itself should be visible")
+	}
+	if vis[Hex{2, 0}] || vis[Hex{3, 0}] {
+		t.Errorf("hexes behind opaque hex should not be visible")
+	}
+	if !vis[Hex{-3, 0}] || !vis[Hex{0, 3}] {
+		t.Errorf("unobstructed hexes should be visible")
+	}
+	if got := HexFOV(Hex{0, 0}, 2, func(Hex) bool { return false }); !reflect.DeepEqual(len(got), 19) {
+		t.Errorf("len(HexFOV) = %d, want 19", len(got))
+	}
+}
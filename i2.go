@@ -14,6 +14,12 @@
 
 package vec
 
+import (
+	"cmp"
+	"math"
+	"math/bits"
+)
+
 // I2 is a pair of integers, (X,Y).
 type I2 struct{ X, Y int }
 
@@ -45,6 +51,9 @@ func (v I2) Mul(k int) I2 { return I2{v.X * k, v.Y * k} }
 func (v I2) Div(k int) I2 { return I2{v.X / k, v.Y / k} }
 
 // MulDiv does both scalar multiplication and division, and tries to avoid overflow on 32-bit.
+// The products are computed in int64, so it is exact (up to integer division, which
+// truncates towards zero) if each component times n has absolute value less than 2^63
+// and the quotients fit in an int.
 func (v I2) MulDiv(n, d int64) I2 {
 	vx, vy := v.C64()
 	vx *= n
@@ -91,26 +100,114 @@ func (v I2) ClampHi(e I2) I2 {
 	return v
 }
 
-// Dot returns the dot product, v dot w.
+// Dot returns the dot product, v dot w. It is exact if all components have
+// absolute value less than 2^31.
 func (v I2) Dot(w I2) int64 { return int64(v.X)*int64(w.X) + int64(v.Y)*int64(w.Y) }
 
+// Norm returns the length of v (the square root of v dot v). Unlike Dot,
+// it does not overflow for large components.
+func (v I2) Norm() float64 { return math.Hypot(float64(v.X), float64(v.Y)) }
+
+// Unit returns the unit vector pointing in the same direction as v.
+func (v I2) Unit() F2 { return v.F2().Unit() }
+
 // Normal returns a vector perpendicular to v of the same length.
 func (v I2) Normal() I2 { return I2{-v.Y, v.X} }
 
 // Cmul returns the complex product v * w, where the X components are treated as real
-// and the Y components as imaginary.
+// and the Y components as imaginary. It is exact if all components have absolute
+// value less than 2^31 (64-bit int) or 2^15 (32-bit int); see also CmulChecked.
 func (v I2) Cmul(w I2) I2 { return I2{w.X*v.X - w.Y*v.Y, w.Y*v.X + w.X*v.Y} }
 
+// Rot rotates the vector by the angle t, rounding to the nearest integer point.
+func (v I2) Rot(t float64) I2 {
+	w := v.F2().Rot(t)
+	return I2{int(math.Round(w.X)), int(math.Round(w.Y))}
+}
+
 // Swap switches x and y components.
 func (v I2) Swap() I2 { return I2{v.Y, v.X} }
 
-// InRect tests if v is in the rectangle ul-dr.
+// Dir returns the general direction of v (Up, Down, Left, Right), in the
+// same way as F2.Dir.
+func (v I2) Dir() Direction {
+	switch {
+	case v.X >= v.Y && v.X >= -v.Y:
+		return Right
+	case v.Y > v.X && v.Y > -v.X:
+		return Down
+	case v.Y < v.X && v.Y < -v.X:
+		return Up
+	default:
+		return Left
+	}
+}
+
+// Dir8 returns the nearest of the eight directions to v. It is exact: a
+// diagonal direction is returned when v is within 22.5 degrees of it.
+// The zero vector returns Right.
+func (v I2) Dir8() Direction {
+	ax, ay := absU64(v.X), absU64(v.Y)
+	if ax == ay {
+		if ax == 0 {
+			return Right
+		}
+		return diagonal(v.X < 0, v.Y < 0)
+	}
+	// v is within 22.5 degrees of the X axis if ay < (√2-1)*ax,
+	// i.e. (ax+ay)^2 < 2*ax^2. Since ax != ay, ax+ay < 2^64, so the
+	// squares fit in 128 bits.
+	sh, sl := bits.Mul64(ax+ay, ax+ay)
+	xh, xl := twiceSquare(ax)
+	yh, yl := twiceSquare(ay)
+	switch {
+	case cmp128(sh, sl, xh, xl) <= 0:
+		if v.X < 0 {
+			return Left
+		}
+		return Right
+	case cmp128(sh, sl, yh, yl) < 0:
+		if v.Y < 0 {
+			return Up
+		}
+		return Down
+	}
+	return diagonal(v.X < 0, v.Y < 0)
+}
+
+// absU64 returns |x| as a uint64 (which is exact even for math.MinInt).
+func absU64(x int) uint64 {
+	if x < 0 {
+		return -uint64(x)
+	}
+	return uint64(x)
+}
+
+// twiceSquare returns 2*x*x as a 128-bit number, for x <= 2^63.
+func twiceSquare(x uint64) (hi, lo uint64) {
+	hi, lo = bits.Mul64(x, x)
+	return hi<<1 | lo>>63, lo << 1
+}
+
+// cmp128 compares the 128-bit numbers ah:al and bh:bl.
+func cmp128(ah, al, bh, bl uint64) int {
+	if ah != bh {
+		return cmp.Compare(ah, bh)
+	}
+	return cmp.Compare(al, bl)
+}
+
+// InRect tests if v is in the rectangle ul-dr, including all its edges.
+// It is Rect{ul, dr}.ContainsWith(v, Closed); note that Rect.Contains
+// excludes the right and bottom edges.
 func (v I2) InRect(ul, dr I2) bool {
-	return v.X >= ul.X && v.X <= dr.X && v.Y >= ul.Y && v.Y <= dr.Y
+	return Rect{ul, dr}.ContainsWith(v, Closed)
 }
 
 // LineIntersectI finds the intersection of the lines (infinite) through p,q and a,b,
-// or returns false if they are parallel.
+// or returns false if they are parallel. It is exact (up to integer division) if all
+// coordinates have absolute value less than 2^20 (64-bit int) or 2^9 (32-bit int);
+// see also LineIntersectIChecked.
 func LineIntersectI(p, q, a, b I2) (I2, bool) {
 	dx1, dy1, dx2, dy2 := p.X-q.X, p.Y-q.Y, a.X-b.X, a.Y-b.Y
 	det := dx2*dy1 - dx1*dy2
@@ -144,7 +241,9 @@ func Sgn(x int) int {
 
 // SegmentIntersectI tests for the intersection of the line segments p-q, a-b. If
 // there is an intersection an approximation of the point of intersection will
-// be returned.
+// be returned. It is exact (up to integer division) if all coordinates have
+// absolute value less than 2^19 (64-bit int) or 2^14 (32-bit int); see also
+// SegmentIntersectIChecked.
 func SegmentIntersectI(p, q, a, b I2) (I2, bool) {
 	dx1, dy1, dx2, dy2 := p.X-q.X, p.Y-q.Y, a.X-b.X, a.Y-b.Y
 	det := dy2*dx1 - dx2*dy1
@@ -163,7 +262,8 @@ func SegmentIntersectI(p, q, a, b I2) (I2, bool) {
 	return b.Sub(a).MulDiv(int64(t), int64(Abs(det))).Add(a), true
 }
 
-// SignedArea2 returns double the signed area of the triangle abc.
+// SignedArea2 returns double the signed area of the triangle abc. It is exact
+// if all coordinates have absolute value less than 2^30.
 func SignedArea2(a, b, c I2) int64 {
 	ax, ay := a.C64()
 	bx, by := b.C64()
@@ -172,7 +272,8 @@ func SignedArea2(a, b, c I2) int64 {
 }
 
 // LineNearestPoint locates the point on the line passing through uv that is closest to p,
-// and returns the point and the square of the distance.
+// and returns the point and the square of the distance. It is exact (up to integer
+// division) if all coordinates have absolute value less than 2^14.
 func LineNearestPoint(u, v, p I2) (I2, int64) {
 	if u == v {
 		p = p.Sub(u)
@@ -186,7 +287,8 @@ func LineNearestPoint(u, v, p I2) (I2, int64) {
 }
 
 // SegmentNearestPoint locates the point on the line segment uv that is closest to p,
-// and returns the point and the square of the distance.
+// and returns the point and the square of the distance. It is exact (up to integer
+// division) if all coordinates have absolute value less than 2^14.
 func SegmentNearestPoint(u, v, p I2) (I2, int64) {
 	q, d := LineNearestPoint(u, v, p)
 	if u == v {
@@ -208,6 +310,7 @@ func SegmentNearestPoint(u, v, p I2) (I2, int64) {
 }
 
 // RectRange makes a list of integer points contained in the Cartesian product [ul.X, dr.X) * [ul.Y, dr.Y).
+// See also Rect.Points, which does the same without allocating.
 func RectRange(ul, dr I2) []I2 {
 	r := make([]I2, 0, (dr.X-ul.X)*(dr.Y-ul.Y))
 	for x := ul.X; x < dr.X; x++ {
@@ -14,7 +14,10 @@
 
 package vec
 
-import "testing"
+import (
+	"math/bits"
+	"testing"
+)
 
 func TestSegmentIntersectI(t *testing.T) {
 	tests := []struct {
@@ -75,3 +78,16 @@ func TestSegmentNearestPoint(t *testing.T) {
 		}
 	}
 }
+
+func TestI2Norm(t *testing.T) {
+	if got, want := (I2{3, -4}).Norm(), 5.0; got != want {
+		t.Errorf("Norm: got %f, want %f", got, want)
+	}
+	if s := 32; bits.UintSize == 64 {
+		// Dot overflows int64 here.
+		v := I2{3 << s, 4 << s}
+		if got, want := v.Norm(), float64(5<<32); got != want {
+			t.Errorf("Norm (large): got %f, want %f", got, want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import "math"
+
+// I3 is a triple of integers, (X,Y,Z).
+type I3 struct{ X, Y, Z int }
+
+// NewI3 is a convenience function for creating an I3.
+func NewI3(x, y, z int) I3 { return I3{x, y, z} }
+
+// I3 extends v into 3D with the given Z component.
+func (v I2) I3(z int) I3 { return I3{v.X, v.Y, z} }
+
+// C returns the components of v (X,Y,Z).
+func (v I3) C() (int, int, int) { return v.X, v.Y, v.Z }
+
+// C64 returns the components of v (X,Y,Z) as int64s.
+func (v I3) C64() (int64, int64, int64) { return int64(v.X), int64(v.Y), int64(v.Z) }
+
+// XY projects v onto the XY plane by dropping the Z component.
+func (v I3) XY() I2 { return I2{v.X, v.Y} }
+
+// F3 casts this I3 to an F3, which may potentially cause loss of precision.
+func (v I3) F3() F3 { return F3{float64(v.X), float64(v.Y), float64(v.Z)} }
+
+// Add returns v + w.
+func (v I3) Add(w I3) I3 { return I3{v.X + w.X, v.Y + w.Y, v.Z + w.Z} }
+
+// Sub returns v - w.
+func (v I3) Sub(w I3) I3 { return I3{v.X - w.X, v.Y - w.Y, v.Z - w.Z} }
+
+// Mul returns the scalar product k * v.
+func (v I3) Mul(k int) I3 { return I3{v.X * k, v.Y * k, v.Z * k} }
+
+// Div integer-divides all components by k.
+func (v I3) Div(k int) I3 { return I3{v.X / k, v.Y / k, v.Z / k} }
+
+// MulDiv does both scalar multiplication and division, and tries to avoid overflow on 32-bit.
+func (v I3) MulDiv(n, d int64) I3 {
+	vx, vy, vz := v.C64()
+	return I3{int(vx * n / d), int(vy * n / d), int(vz * n / d)}
+}
+
+// EMul returns the element-wise product of v and w.
+func (v I3) EMul(w I3) I3 { return I3{v.X * w.X, v.Y * w.Y, v.Z * w.Z} }
+
+// EDiv returns the element-wise quotient of v and w.
+func (v I3) EDiv(w I3) I3 { return I3{v.X / w.X, v.Y / w.Y, v.Z / w.Z} }
+
+// Sgn returns a "unit-ish" vector (each component is normalised).
+func (v I3) Sgn() I3 { return I3{Sgn(v.X), Sgn(v.Y), Sgn(v.Z)} }
+
+// Volume returns the product of X, Y and Z.
+func (v I3) Volume() int { return v.X * v.Y * v.Z }
+
+// ClampLo returns v, but with components clamped below by components of e.
+func (v I3) ClampLo(e I3) I3 {
+	if v.X < e.X {
+		v.X = e.X
+	}
+	if v.Y < e.Y {
+		v.Y = e.Y
+	}
+	if v.Z < e.Z {
+		v.Z = e.Z
+	}
+	return v
+}
+
+// ClampHi returns v, but with components clamped above by components of e.
+func (v I3) ClampHi(e I3) I3 {
+	if v.X >= e.X {
+		v.X = e.X
+	}
+	if v.Y >= e.Y {
+		v.Y = e.Y
+	}
+	if v.Z >= e.Z {
+		v.Z = e.Z
+	}
+	return v
+}
+
+// Dot returns the dot product, v dot w.
+func (v I3) Dot(w I3) int64 {
+	return int64(v.X)*int64(w.X) + int64(v.Y)*int64(w.Y) + int64(v.Z)*int64(w.Z)
+}
+
+// Cross returns the cross product, v cross w.
+func (v I3) Cross(w I3) I3 {
+	return I3{v.Y*w.Z - v.Z*w.Y, v.Z*w.X - v.X*w.Z, v.X*w.Y - v.Y*w.X}
+}
+
+// Norm returns the length of v (the square root of v dot v).
+func (v I3) Norm() float64 { return math.Sqrt(float64(v.Dot(v))) }
+
+// Unit returns the unit vector pointing in the same direction as v.
+func (v I3) Unit() F3 { return v.F3().Unit() }
+
+// LineNearestPoint3 locates the point on the line passing through uv that is closest to p,
+// and returns the point and the square of the distance.
+func LineNearestPoint3(u, v, p I3) (I3, int64) {
+	pu := p.Sub(u)
+	if u == v {
+		return u, pu.Dot(pu)
+	}
+	vu := v.Sub(u)
+	n2 := vu.Dot(vu)
+	c := vu.Cross(pu)
+	return vu.MulDiv(vu.Dot(pu), n2).Add(u), c.Dot(c) / n2
+}
+
+// SegmentNearestPoint3 locates the point on the line segment uv that is closest to p,
+// and returns the point and the square of the distance.
+func SegmentNearestPoint3(u, v, p I3) (I3, int64) {
+	pu, vu := p.Sub(u), v.Sub(u)
+	if pu.Dot(vu) <= 0 {
+		return u, pu.Dot(pu)
+	}
+	if vp := v.Sub(p); vp.Dot(vu) <= 0 {
+		return v, vp.Dot(vp)
+	}
+	return LineNearestPoint3(u, v, p)
+}
@@ -0,0 +1,62 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import "testing"
+
+func TestI3Cross(t *testing.T) {
+	if got, want := (I3{1, 0, 0}.Cross(I3{0, 1, 0})), (I3{0, 0, 1}); got != want {
+		t.Errorf("cross: got %v want %v", got, want)
+	}
+	if got, want := (I3{0, 1, 0}.Cross(I3{1, 0, 0})), (I3{0, 0, -1}); got != want {
+		t.Errorf("cross: got %v want %v", got, want)
+	}
+	if got, want := (F3{0, 0, 2}.Cross(F3{3, 0, 0})), (F3{0, 6, 0}); got != want {
+		t.Errorf("cross: got %v want %v", got, want)
+	}
+}
+
+func TestI3Projection(t *testing.T) {
+	if got, want := (I2{1, 2}.I3(3)).XY(), (I2{1, 2}); got != want {
+		t.Errorf("projection: got %v want %v", got, want)
+	}
+	if got, want := (F2{1, 2}.F3(3)), (F3{1, 2, 3}); got != want {
+		t.Errorf("extension: got %v want %v", got, want)
+	}
+}
+
+func TestSegmentNearestPoint3(t *testing.T) {
+	tests := []struct {
+		u, v, p I3
+		q       I3
+		d       int64
+	}{
+		{I3{0, 0, 0}, I3{0, 0, 0}, I3{1, 1, 1}, I3{0, 0, 0}, 3},      // u == v
+		{I3{0, 0, 0}, I3{4, 0, 0}, I3{1, 1, 1}, I3{1, 0, 0}, 2},      // p beside line
+		{I3{0, 0, 0}, I3{4, 0, 0}, I3{-1, 0, 1}, I3{0, 0, 0}, 2},     // p before u
+		{I3{0, 0, 0}, I3{4, 0, 0}, I3{6, 0, 0}, I3{4, 0, 0}, 4},      // p after v
+		{I3{-4, -4, -4}, I3{4, 4, 4}, I3{2, -1, -1}, I3{0, 0, 0}, 6}, // p off diagonal
+		{I3{0, 0, 0}, I3{0, 0, 10}, I3{0, 0, 7}, I3{0, 0, 7}, 0},     // along line
+	}
+	for i, test := range tests {
+		q, d := SegmentNearestPoint3(test.u, test.v, test.p)
+		if got, want := q, test.q; got != want {
+			t.Errorf("SegmentNearestPoint3 test #%d: got %v, want %v", i, got, want)
+		}
+		if got, want := d, test.d; got != want {
+			t.Errorf("SegmentNearestPoint3 test #%d: got %d, want %d", i, got, want)
+		}
+	}
+}
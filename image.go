@@ -0,0 +1,73 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"image"
+	"image/color"
+)
+
+// Point converts v to an image.Point.
+func (v I2) Point() image.Point { return image.Point{v.X, v.Y} }
+
+// I2FromPoint converts an image.Point to an I2.
+func I2FromPoint(p image.Point) I2 { return I2{p.X, p.Y} }
+
+// Rectangle converts r to an image.Rectangle. Both are half-open, so they
+// contain the same points.
+func (r Rect) Rectangle() image.Rectangle {
+	return image.Rectangle{Min: r.UL.Point(), Max: r.DR.Point()}
+}
+
+// RectFromRectangle converts an image.Rectangle to a Rect.
+func RectFromRectangle(r image.Rectangle) Rect {
+	return Rect{UL: I2FromPoint(r.Min), DR: I2FromPoint(r.Max)}
+}
+
+// GridImage is an image.Image (and draw.Image) whose pixels are the cells
+// of a Grid, so that grids can be drawn or encoded (e.g. with image/png).
+// Grid and ToColor are required, and FromColor is required to draw onto
+// the image; the zero GridImage panics when used.
+type GridImage[T any] struct {
+	Grid *Grid[T]
+
+	// ToColor converts a cell to a pixel colour. It must not be nil.
+	ToColor func(T) color.Color
+
+	// FromColor converts a pixel colour to a cell. It is only needed
+	// for Set (and so for image/draw), but then must not be nil.
+	FromColor func(color.Color) T
+
+	// Model is the colour model of the image. If nil, color.RGBAModel
+	// is used.
+	Model color.Model
+}
+
+// ColorModel returns the colour model of the image.
+func (m GridImage[T]) ColorModel() color.Model {
+	if m.Model == nil {
+		return color.RGBAModel
+	}
+	return m.Model
+}
+
+// Bounds returns the bounds of the grid.
+func (m GridImage[T]) Bounds() image.Rectangle { return m.Grid.Bounds.Rectangle() }
+
+// At returns the colour of the cell at (x, y).
+func (m GridImage[T]) At(x, y int) color.Color { return m.ToColor(m.Grid.At(I2{x, y})) }
+
+// Set sets the cell at (x, y) from the colour c.
+func (m GridImage[T]) Set(x, y int, c color.Color) { m.Grid.Set(I2{x, y}, m.FromColor(c)) }
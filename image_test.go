@@ -0,0 +1,119 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"testing"
+)
+
+func TestImageConversions(t *testing.T) {
+	tests := []Rect{
+		NewRect(0, 0, 0, 0),
+		NewRect(-3, 4, 5, 9),
+		NewRect(7, 7, 2, 2),
+	}
+	for i, r := range tests {
+		ir := r.Rectangle()
+		if got := RectFromRectangle(ir); got != r {
+			t.Errorf("RectFromRectangle test #%d: got %v, want %v", i, got, r)
+		}
+		if got, want := ir.Empty(), r.Empty(); got != want {
+			t.Errorf("Rectangle test #%d: Empty() = %t, want %t", i, got, want)
+		}
+		for p := range r.Canon().Expand(I2{1, 1}).Points(RowMajor) {
+			if got, want := p.Point().In(ir), r.Contains(p); got != want {
+				t.Errorf("Rectangle test #%d: %v.In(%v) = %t, want %t", i, p, ir, got, want)
+			}
+			if got := I2FromPoint(p.Point()); got != p {
+				t.Errorf("I2FromPoint test #%d: got %v, want %v", i, got, p)
+			}
+		}
+	}
+}
+
+func TestGridImage(t *testing.T) {
+	g := NewGrid[bool](NewRect(-2, -1, 3, 2))
+	for p := range (Raster{Clip: g.Bounds}).Circle(I2{}, 1) {
+		g.Set(p, true)
+	}
+	m := GridImage[bool]{
+		Grid: g,
+		ToColor: func(b bool) color.Color {
+			if b {
+				return color.White
+			}
+			return color.Black
+		},
+		FromColor: func(c color.Color) bool {
+			y := color.GrayModel.Convert(c).(color.Gray).Y
+			return y >= 128
+		},
+		Model: color.GrayModel,
+	}
+	var _ draw.Image = m
+
+	if got, want := m.Bounds(), image.Rect(-2, -1, 3, 2); got != want {
+		t.Errorf("Bounds: got %v, want %v", got, want)
+	}
+	if got := m.At(0, 0); got != color.White {
+		t.Errorf("At(0, 0): got %v, want white", got)
+	}
+	if got := m.At(2, 0); got != color.Black {
+		t.Errorf("At(2, 0): got %v, want black", got)
+	}
+	m.Set(2, 1, color.RGBA{255, 255, 255, 255})
+	if !g.At(I2{2, 1}) {
+		t.Errorf("after Set(2, 1, white): got false, want true")
+	}
+	m.Set(9, 9, color.White) // out of bounds; ignored
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, m); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("png.Decode: %v", err)
+	}
+	for p := range g.Bounds.Points(RowMajor) {
+		q := p.Sub(g.Bounds.UL) // PNG images start at (0, 0)
+		if got, want := color.GrayModel.Convert(img.At(q.X, q.Y)), color.GrayModel.Convert(m.At(p.X, p.Y)); got != want {
+			t.Errorf("decoded PNG at %v: got %v, want %v", p, got, want)
+		}
+	}
+}
+
+func TestGrid(t *testing.T) {
+	g := NewGrid[int](NewRect(1, 2, 4, 4))
+	if got, want := len(g.Data), 6; got != want {
+		t.Errorf("len(Data): got %d, want %d", got, want)
+	}
+	g.Set(I2{3, 3}, 7)
+	g.Set(I2{4, 3}, 8) // out of bounds
+	if got, want := g.Data[5], 7; got != want {
+		t.Errorf("Data[5]: got %d, want %d", got, want)
+	}
+	if got := g.At(I2{4, 3}); got != 0 {
+		t.Errorf("At out of bounds: got %d, want 0", got)
+	}
+	if got := NewGrid[int](NewRect(3, 3, 1, 1)).Data; len(got) != 0 {
+		t.Errorf("NewGrid of empty rect: got %d cells, want 0", len(got))
+	}
+}
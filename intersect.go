@@ -0,0 +1,145 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+// IntersectionKind classifies how two line segments intersect.
+type IntersectionKind int
+
+// IntersectionKind values.
+const (
+	// Disjoint segments have no points in common.
+	Disjoint = IntersectionKind(iota)
+	// Crossing segments meet at a single point interior to both.
+	Crossing
+	// TouchingEndpoint segments meet at a single point, which is an endpoint
+	// of at least one of them.
+	TouchingEndpoint
+	// CollinearOverlap segments are collinear and share more than one point.
+	CollinearOverlap
+)
+
+func (k IntersectionKind) String() string {
+	switch k {
+	case Disjoint:
+		return "Disjoint"
+	case Crossing:
+		return "Crossing"
+	case TouchingEndpoint:
+		return "TouchingEndpoint"
+	case CollinearOverlap:
+		return "CollinearOverlap"
+	default:
+		return "IntersectionKind(?)"
+	}
+}
+
+// Intersection describes the intersection of two line segments.
+type Intersection struct {
+	Kind IntersectionKind
+
+	// For Crossing and TouchingEndpoint, the point of intersection is
+	// exactly Num / Den. Den is positive, and the fraction is reduced.
+	Num I2
+	Den int64
+
+	// For CollinearOverlap, the shared sub-segment, with U before V in
+	// lexicographic (X, then Y) order.
+	Overlap Edge
+}
+
+// F2 returns the point of intersection, for Crossing and TouchingEndpoint.
+func (i Intersection) F2() F2 { return i.Num.F2().Div(float64(i.Den)) }
+
+func gcd64(a, b int64) int64 {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// lexLess reports whether u comes before v in lexicographic (X, then Y) order.
+func lexLess(u, v I2) bool {
+	return u.X < v.X || (u.X == v.X && u.Y < v.Y)
+}
+
+// IntersectSegmentsI exactly classifies the intersection of the closed
+// line segments p-q and a-b. Unlike SegmentIntersectI, the point of
+// intersection is exact, and collinear segments are handled. It is exact
+// if all coordinates have absolute value less than 2^19.
+func IntersectSegmentsI(p, q, a, b I2) Intersection {
+	d1, d2 := SignedArea2(a, b, p), SignedArea2(a, b, q)
+	d3, d4 := SignedArea2(p, q, a), SignedArea2(p, q, b)
+
+	if d1 == 0 && d2 == 0 && d3 == 0 && d4 == 0 {
+		// Collinear (or degenerate). Points on a line are ordered
+		// lexicographically, so compare the extents.
+		if lexLess(q, p) {
+			p, q = q, p
+		}
+		if lexLess(b, a) {
+			a, b = b, a
+		}
+		lo, hi := p, q
+		if lexLess(lo, a) {
+			lo = a
+		}
+		if lexLess(b, hi) {
+			hi = b
+		}
+		switch {
+		case lexLess(hi, lo):
+			return Intersection{Kind: Disjoint}
+		case lo == hi:
+			return Intersection{Kind: TouchingEndpoint, Num: lo, Den: 1}
+		default:
+			return Intersection{Kind: CollinearOverlap, Overlap: Edge{lo, hi}}
+		}
+	}
+
+	if (d1 > 0 && d2 > 0) || (d1 < 0 && d2 < 0) || (d3 > 0 && d4 > 0) || (d3 < 0 && d4 < 0) {
+		return Intersection{Kind: Disjoint}
+	}
+
+	switch {
+	case d1 == 0:
+		return Intersection{Kind: TouchingEndpoint, Num: p, Den: 1}
+	case d2 == 0:
+		return Intersection{Kind: TouchingEndpoint, Num: q, Den: 1}
+	case d3 == 0:
+		return Intersection{Kind: TouchingEndpoint, Num: a, Den: 1}
+	case d4 == 0:
+		return Intersection{Kind: TouchingEndpoint, Num: b, Den: 1}
+	}
+
+	// The intersection is p + (q-p) * d1/(d1-d2) = (q*d1 - p*d2) / (d1-d2).
+	px, py := p.C64()
+	qx, qy := q.C64()
+	nx, ny, den := qx*d1-px*d2, qy*d1-py*d2, d1-d2
+	if den < 0 {
+		nx, ny, den = -nx, -ny, -den
+	}
+	g := gcd64(gcd64(nx, ny), den)
+	return Intersection{
+		Kind: Crossing,
+		Num:  I2{int(nx / g), int(ny / g)},
+		Den:  den / g,
+	}
+}
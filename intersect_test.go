@@ -0,0 +1,44 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import "testing"
+
+func TestIntersectSegmentsI(t *testing.T) {
+	tests := []struct {
+		p, q, a, b I2
+		want       Intersection
+	}{
+		{I2{0, 0}, I2{2, 2}, I2{0, 2}, I2{2, 0}, Intersection{Kind: Crossing, Num: I2{1, 1}, Den: 1}},
+		{I2{0, 0}, I2{1, 1}, I2{0, 1}, I2{1, 0}, Intersection{Kind: Crossing, Num: I2{1, 1}, Den: 2}},
+		{I2{0, 0}, I2{3, 0}, I2{1, -1}, I2{2, 2}, Intersection{Kind: Crossing, Num: I2{4, 0}, Den: 3}},
+		{I2{0, 0}, I2{1, 1}, I2{0, 0}, I2{0, 1}, Intersection{Kind: TouchingEndpoint, Num: I2{0, 0}, Den: 1}},
+		{I2{0, 0}, I2{2, 0}, I2{1, 0}, I2{1, 5}, Intersection{Kind: TouchingEndpoint, Num: I2{1, 0}, Den: 1}},
+		{I2{-1, 0}, I2{1, 0}, I2{-1, -1}, I2{1, -1}, Intersection{Kind: Disjoint}}, // Parallel
+		{I2{-1, -1}, I2{1, 1}, I2{0, 3}, I2{3, 0}, Intersection{Kind: Disjoint}},
+		{I2{0, 0}, I2{4, 4}, I2{6, 6}, I2{2, 2}, Intersection{Kind: CollinearOverlap, Overlap: Edge{I2{2, 2}, I2{4, 4}}}},
+		{I2{0, 0}, I2{0, 4}, I2{0, 4}, I2{0, 8}, Intersection{Kind: TouchingEndpoint, Num: I2{0, 4}, Den: 1}},
+		{I2{0, 0}, I2{0, 4}, I2{0, 5}, I2{0, 8}, Intersection{Kind: Disjoint}},
+		{I2{3, 3}, I2{3, 3}, I2{0, 0}, I2{6, 6}, Intersection{Kind: TouchingEndpoint, Num: I2{3, 3}, Den: 1}},
+	}
+	for i, test := range tests {
+		if got := IntersectSegmentsI(test.p, test.q, test.a, test.b); got != test.want {
+			t.Errorf("IntersectSegmentsI test #%d: got %+v, want %+v", i, got, test.want)
+		}
+		if got := IntersectSegmentsI(test.a, test.b, test.p, test.q); got.Kind != test.want.Kind {
+			t.Errorf("IntersectSegmentsI test #%d swapped: got %v, want %v", i, got.Kind, test.want.Kind)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+// Boundary says whether an interval (or rectangle) includes its upper end.
+type Boundary int
+
+// Boundary values.
+const (
+	// HalfOpen intervals [Lo, Hi) include Lo but not Hi. Rect and FRect
+	// are half-open.
+	HalfOpen = Boundary(iota)
+	// Closed intervals [Lo, Hi] include both Lo and Hi. I2.InRect and
+	// F2.InRect use closed rectangles.
+	Closed
+)
+
+// Interval is a range of ints from Lo to Hi. Whether it includes Hi is
+// chosen by the Boundary passed to each method that depends on it.
+type Interval struct{ Lo, Hi int }
+
+// FInterval is a range of float64s from Lo to Hi. Whether it includes Hi is
+// chosen by the Boundary passed to each method that depends on it.
+type FInterval struct{ Lo, Hi float64 }
+
+// contains reports whether x is in [lo, hi) or [lo, hi].
+func contains[T Number](lo, hi, x T, b Boundary) bool {
+	if b == Closed {
+		return lo <= x && x <= hi
+	}
+	return lo <= x && x < hi
+}
+
+// empty reports whether [lo, hi) or [lo, hi] contains nothing.
+func empty[T Number](lo, hi T, b Boundary) bool {
+	if b == Closed {
+		return !(lo <= hi)
+	}
+	return !(lo < hi)
+}
+
+// overlaps reports whether [lo0, hi0) and [lo1, hi1) (or the closed
+// equivalents) have any point in common.
+func overlaps[T Number](lo0, hi0, lo1, hi1 T, b Boundary) bool {
+	return !empty(max(lo0, lo1), min(hi0, hi1), b)
+}
+
+// Contains reports whether x is in i.
+func (i Interval) Contains(x int, b Boundary) bool { return contains(i.Lo, i.Hi, x, b) }
+
+// Empty reports whether i contains nothing. Closed intervals with Lo == Hi
+// contain one point.
+func (i Interval) Empty(b Boundary) bool { return empty(i.Lo, i.Hi, b) }
+
+// Overlaps reports whether i and j have any point in common. Half-open
+// intervals that only touch (i.Hi == j.Lo) don't overlap; closed ones do.
+func (i Interval) Overlaps(j Interval, b Boundary) bool {
+	return overlaps(i.Lo, i.Hi, j.Lo, j.Hi, b)
+}
+
+// Length returns the number of ints in i: Hi - Lo if half-open, or one
+// more if closed. It is 0 if i is empty.
+func (i Interval) Length(b Boundary) int {
+	if b == Closed {
+		return max(i.Hi-i.Lo+1, 0)
+	}
+	return max(i.Hi-i.Lo, 0)
+}
+
+// Canon returns i with Lo and Hi swapped if needed, so that Lo <= Hi.
+func (i Interval) Canon() Interval { return Interval{min(i.Lo, i.Hi), max(i.Lo, i.Hi)} }
+
+// Intersect returns the interval of points in both i and j (under either
+// Boundary). It may be empty.
+func (i Interval) Intersect(j Interval) Interval {
+	return Interval{max(i.Lo, j.Lo), min(i.Hi, j.Hi)}
+}
+
+// Union returns the smallest interval containing both i and j. Intervals
+// that are empty under b are ignored.
+func (i Interval) Union(j Interval, b Boundary) Interval {
+	switch {
+	case i.Empty(b):
+		return j
+	case j.Empty(b):
+		return i
+	}
+	return Interval{min(i.Lo, j.Lo), max(i.Hi, j.Hi)}
+}
+
+// Contains reports whether x is in i.
+func (i FInterval) Contains(x float64, b Boundary) bool { return contains(i.Lo, i.Hi, x, b) }
+
+// Empty reports whether i contains nothing. Intervals with a NaN end are
+// always empty.
+func (i FInterval) Empty(b Boundary) bool { return empty(i.Lo, i.Hi, b) }
+
+// Overlaps reports whether i and j have any point in common. Half-open
+// intervals that only touch (i.Hi == j.Lo) don't overlap; closed ones do.
+func (i FInterval) Overlaps(j FInterval, b Boundary) bool {
+	return overlaps(i.Lo, i.Hi, j.Lo, j.Hi, b)
+}
+
+// Length returns Hi - Lo, or 0 if that is negative (or NaN). Unlike
+// Interval.Length, it is the same under either Boundary, since including
+// the single point Hi adds no length.
+func (i FInterval) Length() float64 {
+	if l := i.Hi - i.Lo; l > 0 {
+		return l
+	}
+	return 0
+}
+
+// Canon returns i with Lo and Hi swapped if needed, so that Lo <= Hi.
+func (i FInterval) Canon() FInterval { return FInterval{min(i.Lo, i.Hi), max(i.Lo, i.Hi)} }
+
+// Intersect returns the interval of points in both i and j (under either
+// Boundary). It may be empty.
+func (i FInterval) Intersect(j FInterval) FInterval {
+	return FInterval{max(i.Lo, j.Lo), min(i.Hi, j.Hi)}
+}
+
+// Union returns the smallest interval containing both i and j. Intervals
+// that are empty under b are ignored.
+func (i FInterval) Union(j FInterval, b Boundary) FInterval {
+	switch {
+	case i.Empty(b):
+		return j
+	case j.Empty(b):
+		return i
+	}
+	return FInterval{min(i.Lo, j.Lo), max(i.Hi, j.Hi)}
+}
+
+// X returns the extent of r along the X axis.
+func (r Rect) X() Interval { return Interval{r.UL.X, r.DR.X} }
+
+// Y returns the extent of r along the Y axis.
+func (r Rect) Y() Interval { return Interval{r.UL.Y, r.DR.Y} }
+
+// RectFromIntervals returns the Rect with extents x and y.
+func RectFromIntervals(x, y Interval) Rect { return NewRect(x.Lo, y.Lo, x.Hi, y.Hi) }
+
+// ContainsWith reports whether p is in r, where r includes its right and
+// bottom edges if b is Closed. ContainsWith(p, HalfOpen) is Contains(p).
+func (r Rect) ContainsWith(p I2, b Boundary) bool {
+	return r.X().Contains(p.X, b) && r.Y().Contains(p.Y, b)
+}
+
+// OverlapsWith reports whether r and s have any point in common, where
+// both include their right and bottom edges if b is Closed (so rectangles
+// that only touch overlap). OverlapsWith(s, HalfOpen) is Overlaps(s).
+func (r Rect) OverlapsWith(s Rect, b Boundary) bool {
+	return r.X().Overlaps(s.X(), b) && r.Y().Overlaps(s.Y(), b)
+}
+
+// X returns the extent of r along the X axis.
+func (r FRect) X() FInterval { return FInterval{r.UL.X, r.DR.X} }
+
+// Y returns the extent of r along the Y axis.
+func (r FRect) Y() FInterval { return FInterval{r.UL.Y, r.DR.Y} }
+
+// FRectFromIntervals returns the FRect with extents x and y.
+func FRectFromIntervals(x, y FInterval) FRect { return NewFRect(x.Lo, y.Lo, x.Hi, y.Hi) }
+
+// ContainsWith reports whether p is in r, where r includes its right and
+// bottom edges if b is Closed. ContainsWith(p, HalfOpen) is Contains(p).
+func (r FRect) ContainsWith(p F2, b Boundary) bool {
+	return r.X().Contains(p.X, b) && r.Y().Contains(p.Y, b)
+}
+
+// OverlapsWith reports whether r and s have any point in common, where
+// both include their right and bottom edges if b is Closed (so rectangles
+// that only touch overlap). OverlapsWith(s, HalfOpen) is Overlaps(s).
+func (r FRect) OverlapsWith(s FRect, b Boundary) bool {
+	return r.X().Overlaps(s.X(), b) && r.Y().Overlaps(s.Y(), b)
+}
@@ -0,0 +1,164 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIntervalContains(t *testing.T) {
+	tests := []struct {
+		i              Interval
+		x              int
+		halfOpen, clsd bool
+	}{
+		{Interval{0, 5}, 0, true, true},
+		{Interval{0, 5}, 4, true, true},
+		{Interval{0, 5}, 5, false, true},
+		{Interval{0, 5}, -1, false, false},
+		{Interval{3, 3}, 3, false, true},
+		{Interval{4, 3}, 3, false, false},
+	}
+	for i, test := range tests {
+		if got := test.i.Contains(test.x, HalfOpen); got != test.halfOpen {
+			t.Errorf("Contains(HalfOpen) test #%d: got %t, want %t", i, got, test.halfOpen)
+		}
+		if got := test.i.Contains(test.x, Closed); got != test.clsd {
+			t.Errorf("Contains(Closed) test #%d: got %t, want %t", i, got, test.clsd)
+		}
+		f := FInterval{float64(test.i.Lo), float64(test.i.Hi)}
+		if got := f.Contains(float64(test.x), HalfOpen); got != test.halfOpen {
+			t.Errorf("FInterval.Contains(HalfOpen) test #%d: got %t, want %t", i, got, test.halfOpen)
+		}
+		if got := f.Contains(float64(test.x), Closed); got != test.clsd {
+			t.Errorf("FInterval.Contains(Closed) test #%d: got %t, want %t", i, got, test.clsd)
+		}
+	}
+}
+
+func TestIntervalOps(t *testing.T) {
+	tests := []struct {
+		i, j             Interval
+		intersect, union Interval
+		halfOpen, clsd   bool
+	}{
+		{Interval{0, 5}, Interval{3, 8}, Interval{3, 5}, Interval{0, 8}, true, true},
+		{Interval{0, 5}, Interval{5, 8}, Interval{5, 5}, Interval{0, 8}, false, true},
+		{Interval{0, 5}, Interval{6, 8}, Interval{6, 5}, Interval{0, 8}, false, false},
+		{Interval{2, 3}, Interval{0, 8}, Interval{2, 3}, Interval{0, 8}, true, true},
+		{Interval{4, 4}, Interval{0, 8}, Interval{4, 4}, Interval{0, 8}, false, true},
+	}
+	for i, test := range tests {
+		if got := test.i.Intersect(test.j); got != test.intersect {
+			t.Errorf("Intersect test #%d: got %v, want %v", i, got, test.intersect)
+		}
+		if got := test.i.Union(test.j, HalfOpen); got != test.union {
+			t.Errorf("Union test #%d: got %v, want %v", i, got, test.union)
+		}
+		for _, b := range []Boundary{HalfOpen, Closed} {
+			want := test.halfOpen
+			if b == Closed {
+				want = test.clsd
+			}
+			if got := test.i.Overlaps(test.j, b); got != want {
+				t.Errorf("Overlaps(%d) test #%d: got %t, want %t", b, i, got, want)
+			}
+			if got := test.j.Overlaps(test.i, b); got != want {
+				t.Errorf("Overlaps(%d) reversed test #%d: got %t, want %t", b, i, got, want)
+			}
+			if got := !test.i.Intersect(test.j).Empty(b); got != want {
+				t.Errorf("Intersect(%d) test #%d: non-empty = %t, want %t", b, i, got, want)
+			}
+		}
+	}
+	if got, want := (Interval{7, 2}).Length(HalfOpen), 0; got != want {
+		t.Errorf("Length(HalfOpen): got %d, want %d", got, want)
+	}
+	if got, want := (Interval{2, 7}).Length(Closed), 6; got != want {
+		t.Errorf("Length(Closed): got %d, want %d", got, want)
+	}
+	if got, want := (Interval{3, 3}).Length(Closed), 1; got != want {
+		t.Errorf("Length(Closed): got %d, want %d", got, want)
+	}
+	if got, want := (Interval{7, 2}).Canon(), (Interval{2, 7}); got != want {
+		t.Errorf("Canon: got %v, want %v", got, want)
+	}
+	if got, want := (FInterval{1.5, 4}).Length(), 2.5; got != want {
+		t.Errorf("FInterval.Length: got %v, want %v", got, want)
+	}
+	nan := FInterval{math.NaN(), 1}
+	if nan.Length() != 0 || !nan.Empty(Closed) || nan.Overlaps(FInterval{0, 2}, Closed) {
+		t.Errorf("FInterval with NaN: want zero length, empty, and no overlaps")
+	}
+}
+
+func TestIntervalUnionEmpty(t *testing.T) {
+	tests := []struct {
+		i, j           Interval
+		halfOpen, clsd Interval
+	}{
+		{Interval{5, 3}, Interval{0, 2}, Interval{0, 2}, Interval{0, 2}},
+		{Interval{0, 2}, Interval{5, 3}, Interval{0, 2}, Interval{0, 2}},
+		{Interval{3, 3}, Interval{6, 8}, Interval{6, 8}, Interval{3, 8}},
+		{Interval{6, 8}, Interval{3, 3}, Interval{6, 8}, Interval{3, 8}},
+	}
+	for i, test := range tests {
+		if got := test.i.Union(test.j, HalfOpen); got != test.halfOpen {
+			t.Errorf("Union(HalfOpen) test #%d: got %v, want %v", i, got, test.halfOpen)
+		}
+		if got := test.i.Union(test.j, Closed); got != test.clsd {
+			t.Errorf("Union(Closed) test #%d: got %v, want %v", i, got, test.clsd)
+		}
+		f, g := FInterval{float64(test.i.Lo), float64(test.i.Hi)}, FInterval{float64(test.j.Lo), float64(test.j.Hi)}
+		if got, want := f.Union(g, HalfOpen), (FInterval{float64(test.halfOpen.Lo), float64(test.halfOpen.Hi)}); got != want {
+			t.Errorf("FInterval.Union(HalfOpen) test #%d: got %v, want %v", i, got, want)
+		}
+		if got, want := f.Union(g, Closed), (FInterval{float64(test.clsd.Lo), float64(test.clsd.Hi)}); got != want {
+			t.Errorf("FInterval.Union(Closed) test #%d: got %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestRectBoundaries(t *testing.T) {
+	r := NewRect(0, 0, 4, 3)
+	if got := RectFromIntervals(r.X(), r.Y()); got != r {
+		t.Errorf("RectFromIntervals: got %v, want %v", got, r)
+	}
+	for p := range NewRect(-1, -1, 6, 5).Points(RowMajor) {
+		if got, want := r.ContainsWith(p, HalfOpen), r.Contains(p); got != want {
+			t.Errorf("ContainsWith(%v, HalfOpen) = %t, want %t", p, got, want)
+		}
+		if got, want := r.ContainsWith(p, Closed), p.InRect(r.UL, r.DR); got != want {
+			t.Errorf("ContainsWith(%v, Closed) = %t, want %t", p, got, want)
+		}
+		if got, want := r.FRect().ContainsWith(p.F2(), Closed), p.F2().InRect(r.FRect().C()); got != want {
+			t.Errorf("FRect.ContainsWith(%v, Closed) = %t, want %t", p, got, want)
+		}
+	}
+	touching := NewRect(4, 3, 6, 6)
+	if r.OverlapsWith(touching, HalfOpen) || r.Overlaps(touching) {
+		t.Errorf("OverlapsWith(HalfOpen): touching rects should not overlap")
+	}
+	if !r.OverlapsWith(touching, Closed) || !r.FRect().OverlapsWith(touching.FRect(), Closed) {
+		t.Errorf("OverlapsWith(Closed): touching rects should overlap")
+	}
+	if got, want := r.FRect().X(), (FInterval{0, 4}); got != want {
+		t.Errorf("FRect.X: got %v, want %v", got, want)
+	}
+	if got, want := FRectFromIntervals(FInterval{1, 2}, FInterval{3, 4}), NewFRect(1, 3, 2, 4); got != want {
+		t.Errorf("FRectFromIntervals: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import "math"
+
+// Anchor is one of the nine points of a rectangle used to align things:
+// the corners, the middles of the sides, and the centre.
+type Anchor int
+
+// Anchor values.
+const (
+	AnchorTopLeft = Anchor(iota)
+	AnchorTop
+	AnchorTopRight
+	AnchorLeft
+	AnchorCenter
+	AnchorRight
+	AnchorBottomLeft
+	AnchorBottom
+	AnchorBottomRight
+)
+
+// halves returns how far across the anchor is, in halves (0, 1 or 2).
+func (a Anchor) halves() I2 { return I2{int(a) % 3, int(a) / 3} }
+
+// Anchor returns the point of r at the anchor a. Points in the middle are
+// rounded towards UL. Since r is half-open, the right and bottom anchors
+// are on the edges of DR, just outside the points r contains.
+func (r Rect) Anchor(a Anchor) I2 {
+	h := a.halves()
+	sz := r.Size()
+	return I2{r.UL.X + divDown(sz.X*h.X, 2), r.UL.Y + divDown(sz.Y*h.Y, 2)}
+}
+
+// Align returns r moved so that its anchor a is at the anchor a of outer,
+// for example centred in outer (AnchorCenter), or in the bottom-right
+// corner of outer (AnchorBottomRight). When centring would need half
+// a cell, r is placed towards UL.
+func (r Rect) Align(outer Rect, a Anchor) Rect {
+	h := a.halves()
+	d := outer.Size().Sub(r.Size())
+	return r.Reposition(I2{outer.UL.X + divDown(d.X*h.X, 2), outer.UL.Y + divDown(d.Y*h.Y, 2)})
+}
+
+// Insets are distances in from each side of a rectangle.
+type Insets struct {
+	Top, Right, Bottom, Left int
+}
+
+// UniformInsets returns Insets of n on every side.
+func UniformInsets(n int) Insets { return Insets{n, n, n, n} }
+
+// Inset returns r shrunk by the insets (or grown, where they are
+// negative).
+func (r Rect) Inset(in Insets) Rect {
+	return Rect{
+		UL: I2{r.UL.X + in.Left, r.UL.Y + in.Top},
+		DR: I2{r.DR.X - in.Right, r.DR.Y - in.Bottom},
+	}
+}
+
+// SplitLeft splits r into a part n wide on the left and the rest. n is
+// clamped to the width of r.
+func (r Rect) SplitLeft(n int) (left, rest Rect) {
+	x := r.UL.X + max(0, min(n, r.DR.X-r.UL.X))
+	return Rect{UL: r.UL, DR: I2{x, r.DR.Y}}, Rect{UL: I2{x, r.UL.Y}, DR: r.DR}
+}
+
+// SplitRight splits r into a part n wide on the right and the rest. n is
+// clamped to the width of r.
+func (r Rect) SplitRight(n int) (right, rest Rect) {
+	rest, right = r.SplitLeft(r.DR.X - r.UL.X - max(0, n))
+	return right, rest
+}
+
+// SplitTop splits r into a part n high at the top and the rest. n is
+// clamped to the height of r.
+func (r Rect) SplitTop(n int) (top, rest Rect) {
+	y := r.UL.Y + max(0, min(n, r.DR.Y-r.UL.Y))
+	return Rect{UL: r.UL, DR: I2{r.DR.X, y}}, Rect{UL: I2{r.UL.X, y}, DR: r.DR}
+}
+
+// SplitBottom splits r into a part n high at the bottom and the rest. n is
+// clamped to the height of r.
+func (r Rect) SplitBottom(n int) (bottom, rest Rect) {
+	rest, bottom = r.SplitTop(r.DR.Y - r.UL.Y - max(0, n))
+	return bottom, rest
+}
+
+// Axis is a direction along which to divide a rectangle.
+type Axis int
+
+// Axis values.
+const (
+	AxisX = Axis(iota) // side by side, from left to right
+	AxisY              // stacked, from top to bottom
+)
+
+// withSpan returns r with its extent along the axis replaced by [lo, hi).
+func (r Rect) withSpan(a Axis, lo, hi int) Rect {
+	if a == AxisY {
+		r.UL.Y, r.DR.Y = lo, hi
+	} else {
+		r.UL.X, r.DR.X = lo, hi
+	}
+	return r
+}
+
+// span returns the extent of r along the axis.
+func (r Rect) span(a Axis) (lo, hi int) {
+	if a == AxisY {
+		return r.UL.Y, r.DR.Y
+	}
+	return r.UL.X, r.DR.X
+}
+
+// SplitRatios divides r along the axis into parts whose sizes are in
+// proportion to the weights. The parts exactly cover r, with sizes rounded
+// to the nearest integer.
+func (r Rect) SplitRatios(a Axis, weights ...float64) []Rect {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	lo, hi := r.span(a)
+	parts := make([]Rect, len(weights))
+	var cum float64
+	start := lo
+	for i, w := range weights {
+		cum += w
+		end := hi
+		if i < len(weights)-1 && total > 0 {
+			end = lo + int(math.Round(float64(hi-lo)*cum/total))
+		}
+		parts[i] = r.withSpan(a, start, end)
+		start = end
+	}
+	return parts
+}
+
+// SplitSizes divides r along the axis into parts of the given sizes, from
+// the start of r. Sizes are clamped so that no part extends past the end
+// of r.
+func (r Rect) SplitSizes(a Axis, sizes ...int) []Rect {
+	lo, hi := r.span(a)
+	parts := make([]Rect, len(sizes))
+	for i, n := range sizes {
+		end := max(lo, min(lo+n, hi))
+		parts[i] = r.withSpan(a, lo, end)
+		lo = end
+	}
+	return parts
+}
+
+// divide divides r along the axis into n equal parts separated by gap.
+// Any leftover space is spread among the parts, so they differ in size by
+// at most 1. If the gaps alone don't fit, the parts are clamped to r, so
+// those past the end are empty.
+func (r Rect) divide(a Axis, n, gap int) []Rect {
+	if n <= 0 {
+		return nil
+	}
+	lo, hi := r.span(a)
+	avail := max(0, hi-lo-gap*(n-1))
+	parts := make([]Rect, n)
+	for i := range parts {
+		s := lo + i*gap + avail*i/n
+		e := s + avail*(i+1)/n - avail*i/n
+		parts[i] = r.withSpan(a, min(s, hi), min(e, hi))
+	}
+	return parts
+}
+
+// Row divides r into n equal parts side by side, separated by gap.
+func (r Rect) Row(n, gap int) []Rect { return r.divide(AxisX, n, gap) }
+
+// Column divides r into n equal parts stacked vertically, separated by gap.
+func (r Rect) Column(n, gap int) []Rect { return r.divide(AxisY, n, gap) }
+
+// SplitGrid divides r into a grid of cols * rows equal cells, separated
+// horizontally by gap.X and vertically by gap.Y. The cells are returned
+// in row-major order. (To store a value per point of r, see NewGrid.)
+func (r Rect) SplitGrid(cols, rows int, gap I2) []Rect {
+	var cells []Rect
+	for _, row := range r.Column(rows, gap.Y) {
+		cells = append(cells, row.Row(cols, gap.X)...)
+	}
+	return cells
+}
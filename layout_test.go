@@ -0,0 +1,128 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAlign(t *testing.T) {
+	outer := NewRect(10, 20, 30, 30)
+	r := NewRect(0, 0, 5, 4)
+	tests := []struct {
+		a      Anchor
+		want   Rect
+		anchor I2
+	}{
+		{AnchorTopLeft, NewRect(10, 20, 15, 24), I2{10, 20}},
+		{AnchorTop, NewRect(17, 20, 22, 24), I2{20, 20}},
+		{AnchorTopRight, NewRect(25, 20, 30, 24), I2{30, 20}},
+		{AnchorLeft, NewRect(10, 23, 15, 27), I2{10, 25}},
+		{AnchorCenter, NewRect(17, 23, 22, 27), I2{20, 25}},
+		{AnchorRight, NewRect(25, 23, 30, 27), I2{30, 25}},
+		{AnchorBottomLeft, NewRect(10, 26, 15, 30), I2{10, 30}},
+		{AnchorBottom, NewRect(17, 26, 22, 30), I2{20, 30}},
+		{AnchorBottomRight, NewRect(25, 26, 30, 30), I2{30, 30}},
+	}
+	for i, test := range tests {
+		if got := r.Align(outer, test.a); got != test.want {
+			t.Errorf("Align test #%d: got %v, want %v", i, got, test.want)
+		}
+		if got := outer.Anchor(test.a); got != test.anchor {
+			t.Errorf("Anchor test #%d: got %v, want %v", i, got, test.anchor)
+		}
+	}
+	// Larger than outer: overhangs evenly (rounding towards UL).
+	if got, want := NewRect(0, 0, 25, 13).Align(outer, AnchorCenter), NewRect(7, 18, 32, 31); got != want {
+		t.Errorf("Align larger: got %v, want %v", got, want)
+	}
+}
+
+func TestInset(t *testing.T) {
+	r := NewRect(0, 0, 100, 50)
+	if got, want := r.Inset(Insets{Top: 1, Right: 2, Bottom: 3, Left: 4}), NewRect(4, 1, 98, 47); got != want {
+		t.Errorf("Inset: got %v, want %v", got, want)
+	}
+	if got, want := r.Inset(UniformInsets(-5)), NewRect(-5, -5, 105, 55); got != want {
+		t.Errorf("Inset(-5): got %v, want %v", got, want)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	r := NewRect(0, 0, 100, 50)
+	pair := func(a, b Rect) [2]Rect { return [2]Rect{a, b} }
+	tests := []struct {
+		name      string
+		got, want [2]Rect
+	}{
+		{"SplitLeft", pair(r.SplitLeft(30)), pair(NewRect(0, 0, 30, 50), NewRect(30, 0, 100, 50))},
+		{"SplitRight", pair(r.SplitRight(30)), pair(NewRect(70, 0, 100, 50), NewRect(0, 0, 70, 50))},
+		{"SplitTop", pair(r.SplitTop(10)), pair(NewRect(0, 0, 100, 10), NewRect(0, 10, 100, 50))},
+		{"SplitBottom", pair(r.SplitBottom(10)), pair(NewRect(0, 40, 100, 50), NewRect(0, 0, 100, 40))},
+		{"SplitLeft clamped", pair(r.SplitLeft(200)), pair(r, NewRect(100, 0, 100, 50))},
+		{"SplitBottom clamped", pair(r.SplitBottom(-3)), pair(NewRect(0, 50, 100, 50), r)},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, test.got, test.want)
+		}
+	}
+
+	if got, want := r.SplitRatios(AxisX, 1, 2, 1), []Rect{NewRect(0, 0, 25, 50), NewRect(25, 0, 75, 50), NewRect(75, 0, 100, 50)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitRatios(AxisX, 1, 2, 1): got %v, want %v", got, want)
+	}
+	if got, want := r.SplitRatios(AxisY, 1, 1, 1), []Rect{NewRect(0, 0, 100, 17), NewRect(0, 17, 100, 33), NewRect(0, 33, 100, 50)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitRatios(AxisY, 1, 1, 1): got %v, want %v", got, want)
+	}
+	if got, want := r.SplitSizes(AxisY, 10, 20, 30), []Rect{NewRect(0, 0, 100, 10), NewRect(0, 10, 100, 30), NewRect(0, 30, 100, 50)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SplitSizes(AxisY, 10, 20, 30): got %v, want %v", got, want)
+	}
+}
+
+func TestRowColumnSplitGrid(t *testing.T) {
+	r := NewRect(0, 0, 100, 50)
+	if got, want := r.Row(3, 5), []Rect{NewRect(0, 0, 30, 50), NewRect(35, 0, 65, 50), NewRect(70, 0, 100, 50)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Row(3, 5): got %v, want %v", got, want)
+	}
+	if got, want := r.Column(3, 1), []Rect{NewRect(0, 0, 100, 16), NewRect(0, 17, 100, 33), NewRect(0, 34, 100, 50)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Column(3, 1): got %v, want %v", got, want)
+	}
+	if got := r.Row(0, 5); got != nil {
+		t.Errorf("Row(0, 5): got %v, want nil", got)
+	}
+	for i, c := range r.Row(4, 40) {
+		if c.UL.X < r.UL.X || c.DR.X > r.DR.X {
+			t.Errorf("Row(4, 40)[%d]: got %v, want a part inside %v", i, c, r)
+		}
+	}
+	grid := r.SplitGrid(4, 2, I2{4, 2})
+	if len(grid) != 8 {
+		t.Fatalf("SplitGrid(4, 2): got %d cells, want 8", len(grid))
+	}
+	if got, want := grid[0], NewRect(0, 0, 22, 24); got != want {
+		t.Errorf("SplitGrid(4, 2)[0]: got %v, want %v", got, want)
+	}
+	if got, want := grid[7], NewRect(78, 26, 100, 50); got != want {
+		t.Errorf("SplitGrid(4, 2)[7]: got %v, want %v", got, want)
+	}
+	for i, c := range grid {
+		for _, d := range grid[i+1:] {
+			if c.Overlaps(d) {
+				t.Errorf("SplitGrid(4, 2): cells %v and %v overlap", c, d)
+			}
+		}
+	}
+}
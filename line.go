@@ -0,0 +1,123 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import "iter"
+
+// Connectivity selects how SegmentCells rasterises a line.
+type Connectivity int
+
+// Connectivity values.
+const (
+	// Connect8 produces thin (Bresenham) lines, where consecutive cells
+	// may be diagonal neighbours.
+	Connect8 = Connectivity(iota)
+	// Connect4 produces lines where consecutive cells always share an edge.
+	Connect4
+	// Supercover produces every cell the segment touches. Where the segment
+	// passes exactly through a cell corner, both cells beside the corner
+	// are included.
+	Supercover
+)
+
+// ceilDiv returns n/d rounded up, for d > 0.
+func ceilDiv(n, d int) int { return -divDown(-n, d) }
+
+// SegmentCells returns an iterator over the grid cells along the segment
+// from a to b, inclusive, where each cell is the unit square centred on an
+// integer point. It uses only integer arithmetic. The cells are the same
+// whichever way round a and b are given (only the order is reversed).
+func SegmentCells(a, b I2, c Connectivity) iter.Seq[I2] {
+	switch c {
+	case Connect4, Supercover:
+		return walkCells(a, b, c == Supercover)
+	default:
+		return bresenham(a, b)
+	}
+}
+
+// bresenham yields the cells of a thin line from a to b. Rather than
+// accumulating an error term, it computes each minor coordinate exactly,
+// rounding halves towards -Inf, so that the result doesn't depend on the
+// direction.
+func bresenham(a, b I2) iter.Seq[I2] {
+	return func(yield func(I2) bool) {
+		d := b.Sub(a)
+		swap := Abs(d.Y) > Abs(d.X)
+		if swap {
+			a, d = a.Swap(), d.Swap()
+		}
+		n, s := Abs(d.X), Sgn(d.X)
+		for i := 0; i <= n; i++ {
+			p := I2{a.X + i*s, a.Y}
+			if n > 0 {
+				// p.Y += round(i*d.Y/n), with halves rounded down.
+				p.Y += ceilDiv(2*i*d.Y-n, 2*n)
+			}
+			if swap {
+				p = p.Swap()
+			}
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// walkCells yields the cells crossed by the line from a to b, in order. At
+// an exact corner crossing, the cell with smaller Y is used (if
+// supercover is false) or both are yielded, smaller Y first.
+func walkCells(a, b I2, supercover bool) iter.Seq[I2] {
+	return func(yield func(I2) bool) {
+		d := b.Sub(a)
+		s := d.Sgn()
+		nx, ny := Abs(d.X), Abs(d.Y)
+		p := a
+		if !yield(p) {
+			return
+		}
+		for ix, iy := 0, 0; ix < nx || iy < ny; {
+			// Compare the times of the next vertical and horizontal
+			// cell boundary crossings: (2ix+1)/2nx vs (2iy+1)/2ny.
+			tx, ty := int64(2*ix+1)*int64(ny), int64(2*iy+1)*int64(nx)
+			switch {
+			case ix == nx || (iy < ny && tx > ty):
+				p.Y += s.Y
+				iy++
+			case iy == ny || tx < ty:
+				p.X += s.X
+				ix++
+			default:
+				// Exactly through a corner.
+				first, second := I2{p.X + s.X, p.Y}, I2{p.X, p.Y + s.Y}
+				if s.Y < 0 {
+					first, second = second, first
+				}
+				if !yield(first) {
+					return
+				}
+				if supercover && !yield(second) {
+					return
+				}
+				p = p.Add(s)
+				ix++
+				iy++
+			}
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSegmentCells(t *testing.T) {
+	tests := []struct {
+		a, b I2
+		c    Connectivity
+		want []I2
+	}{
+		{I2{2, 3}, I2{2, 3}, Connect8, []I2{{2, 3}}},
+		{I2{2, 3}, I2{2, 3}, Supercover, []I2{{2, 3}}},
+		{I2{0, 0}, I2{3, 0}, Connect8, []I2{{0, 0}, {1, 0}, {2, 0}, {3, 0}}},
+		{I2{0, 0}, I2{0, -2}, Connect4, []I2{{0, 0}, {0, -1}, {0, -2}}},
+		{I2{0, 0}, I2{2, 2}, Connect8, []I2{{0, 0}, {1, 1}, {2, 2}}},
+		{I2{0, 0}, I2{2, 2}, Connect4, []I2{{0, 0}, {1, 0}, {1, 1}, {2, 1}, {2, 2}}},
+		{I2{0, 0}, I2{2, 2}, Supercover, []I2{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {2, 1}, {1, 2}, {2, 2}}},
+		{I2{0, 0}, I2{4, 1}, Connect8, []I2{{0, 0}, {1, 0}, {2, 0}, {3, 1}, {4, 1}}},
+		{I2{0, 0}, I2{4, 1}, Connect4, []I2{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {3, 1}, {4, 1}}},
+		{I2{0, 0}, I2{1, 3}, Connect8, []I2{{0, 0}, {0, 1}, {1, 2}, {1, 3}}},
+		{I2{0, 0}, I2{-3, 2}, Connect4, []I2{{0, 0}, {-1, 0}, {-1, 1}, {-2, 1}, {-2, 2}, {-3, 2}}},
+	}
+	for i, test := range tests {
+		if got := slices.Collect(SegmentCells(test.a, test.b, test.c)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("SegmentCells(%v, %v, %d) test #%d: got %v, want %v", test.a, test.b, test.c, i, got, test.want)
+		}
+	}
+}
+
+func TestSegmentCellsSymmetric(t *testing.T) {
+	for _, c := range []Connectivity{Connect8, Connect4, Supercover} {
+		for x := -5; x <= 5; x++ {
+			for y := -5; y <= 5; y++ {
+				a, b := I2{1, -2}, I2{x, y}
+				fwd := slices.Collect(SegmentCells(a, b, c))
+				rev := slices.Collect(SegmentCells(b, a, c))
+				slices.Reverse(rev)
+				if c == Supercover {
+					slices.SortFunc(fwd, cmpI2)
+					slices.SortFunc(rev, cmpI2)
+				}
+				if !reflect.DeepEqual(fwd, rev) {
+					t.Errorf("SegmentCells(%v, %v, %d): forward %v, reversed %v", a, b, c, fwd, rev)
+				}
+				for i := 1; i < len(fwd) && c != Supercover; i++ {
+					d := fwd[i].Sub(fwd[i-1])
+					if Abs(d.X) > 1 || Abs(d.Y) > 1 || (c == Connect4 && Abs(d.X)+Abs(d.Y) != 1) {
+						t.Errorf("SegmentCells(%v, %v, %d): step %v to %v not connected", a, b, c, fwd[i-1], fwd[i])
+					}
+				}
+			}
+		}
+	}
+}
+
+func cmpI2(u, v I2) int {
+	if u.Y != v.Y {
+		return u.Y - v.Y
+	}
+	return u.X - v.X
+}
+
+func TestSegmentCellsBreak(t *testing.T) {
+	for _, c := range []Connectivity{Connect8, Connect4, Supercover} {
+		n := 0
+		for range SegmentCells(I2{0, 0}, I2{10, 7}, c) {
+			n++
+			if n == 3 {
+				break
+			}
+		}
+		if n != 3 {
+			t.Errorf("SegmentCells(%d) break: got %d cells, want 3", c, n)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// The packing algorithms follow Jukka Jylänki, "A Thousand Ways to Pack
+// the Bin - A Practical Approach to Two-Dimensional Rectangle Bin Packing"
+// (2010).
+
+// PackAlgorithm selects the algorithm used by a Packer.
+type PackAlgorithm int
+
+// PackAlgorithm values.
+const (
+	// MaxRects tracks all maximal free rectangles, and places each item
+	// in the one that leaves the shortest leftover side (best short side
+	// fit). It usually packs most tightly, but is the slowest.
+	MaxRects = PackAlgorithm(iota)
+	// Skyline tracks the top edge of the packed items, and places each
+	// item as low as possible (bottom-left).
+	Skyline
+	// Guillotine splits free rectangles in two after each placement, and
+	// places each item in the free rectangle it fits best by area.
+	Guillotine
+)
+
+// Packer packs rectangles into one or more bins.
+type Packer struct {
+	Algorithm PackAlgorithm
+	Bin       I2   // the size of each bin
+	Padding   int  // space to leave between items
+	Rotate    bool // whether items may be rotated by 90 degrees
+	MaxBins   int  // the maximum number of bins to use, or 0 for no limit
+}
+
+// Placement is where a Packer placed an item.
+type Placement struct {
+	Bin     int  // the index of the bin
+	Rect    Rect // where the item is in the bin
+	Rotated bool // whether the item was rotated (Rect has the swapped size)
+}
+
+// binPacker packs items into a single bin.
+type binPacker interface {
+	// insert places an item of the given size (padding included), or
+	// returns false if it doesn't fit.
+	insert(sz I2, rotate bool) (pos I2, rotated, ok bool)
+}
+
+// Pack places items of the given sizes, returning a placement for each
+// (in the same order as sizes). Larger items are placed first, and earlier
+// bins are filled before later ones. The result depends only on the input.
+// It returns an error if an item can't fit in an empty bin, or if more than
+// MaxBins bins would be needed.
+func (p Packer) Pack(sizes []I2) ([]Placement, error) {
+	// Padding is added to the right and bottom of each item, and the bin
+	// is enlarged to match, so there is no padding at the bin edges.
+	pad := I2{p.Padding, p.Padding}
+	bin := p.Bin.Add(pad)
+
+	order := make([]int, len(sizes))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		a, b := sizes[i], sizes[j]
+		return cmp.Or(
+			cmp.Compare(max(b.X, b.Y), max(a.X, a.Y)),
+			cmp.Compare(min(b.X, b.Y), min(a.X, a.Y)),
+		)
+	})
+
+	out := make([]Placement, len(sizes))
+	var bins []binPacker
+	for _, i := range order {
+		sz := sizes[i]
+		if sz.X < 0 || sz.Y < 0 {
+			return nil, fmt.Errorf("item %d has negative size %v", i, sz)
+		}
+		fits := sz.X <= p.Bin.X && sz.Y <= p.Bin.Y
+		if p.Rotate {
+			fits = fits || (sz.Y <= p.Bin.X && sz.X <= p.Bin.Y)
+		}
+		if !fits {
+			return nil, fmt.Errorf("item %d of size %v is larger than bin size %v", i, sz, p.Bin)
+		}
+		placed := false
+		for b := 0; !placed; b++ {
+			if b == len(bins) {
+				if p.MaxBins > 0 && b == p.MaxBins {
+					return nil, fmt.Errorf("item %d of size %v doesn't fit in %d bins", i, sz, p.MaxBins)
+				}
+				bins = append(bins, p.newBin(bin))
+			}
+			pos, rot, ok := bins[b].insert(sz.Add(pad), p.Rotate)
+			if !ok {
+				continue
+			}
+			if rot {
+				sz = sz.Swap()
+			}
+			out[i] = Placement{Bin: b, Rect: Rect{UL: pos, DR: pos.Add(sz)}, Rotated: rot}
+			placed = true
+		}
+	}
+	return out, nil
+}
+
+// newBin returns an empty bin of the given size.
+func (p Packer) newBin(size I2) binPacker {
+	switch p.Algorithm {
+	case Skyline:
+		return &skylineBin{size: size, sky: []skylineSeg{{0, 0, size.X}}}
+	case Guillotine:
+		return &guillotineBin{free: []Rect{{DR: size}}}
+	default:
+		return &maxRectsBin{free: []Rect{{DR: size}}}
+	}
+}
+
+// orientations returns the sizes to try for an item.
+func orientations(sz I2, rotate bool) []I2 {
+	if rotate && sz.X != sz.Y {
+		return []I2{sz, sz.Swap()}
+	}
+	return []I2{sz}
+}
+
+// maxRectsBin is a bin packed with MaxRects (best short side fit).
+type maxRectsBin struct {
+	free []Rect // maximal free rectangles, which may overlap
+}
+
+func (m *maxRectsBin) insert(sz I2, rotate bool) (pos I2, rotated, ok bool) {
+	var best Rect
+	bestShort, bestLong := 0, 0
+	for _, f := range m.free {
+		fs := f.Size()
+		for k, s := range orientations(sz, rotate) {
+			if s.X > fs.X || s.Y > fs.Y {
+				continue
+			}
+			d := fs.Sub(s)
+			short, long := min(d.X, d.Y), max(d.X, d.Y)
+			if ok && (short > bestShort || (short == bestShort && long >= bestLong)) {
+				continue
+			}
+			best = Rect{UL: f.UL, DR: f.UL.Add(s)}
+			bestShort, bestLong, rotated, ok = short, long, k == 1, true
+		}
+	}
+	if !ok || best.Empty() {
+		return best.UL, rotated, ok
+	}
+
+	// Split every free rectangle overlapping the item into the maximal
+	// rectangles either side of it.
+	var free []Rect
+	for _, f := range m.free {
+		if !f.Overlaps(best) {
+			free = append(free, f)
+			continue
+		}
+		for _, s := range []Rect{
+			{UL: f.UL, DR: I2{best.UL.X, f.DR.Y}},
+			{UL: I2{best.DR.X, f.UL.Y}, DR: f.DR},
+			{UL: f.UL, DR: I2{f.DR.X, best.UL.Y}},
+			{UL: I2{f.UL.X, best.DR.Y}, DR: f.DR},
+		} {
+			if !s.Empty() {
+				free = append(free, s)
+			}
+		}
+	}
+	// Remove free rectangles contained in others (keeping the first of
+	// any duplicates).
+	m.free = m.free[:0]
+	for i, f := range free {
+		contained := false
+		for j, g := range free {
+			if i != j && f.In(g) && (f != g || j < i) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			m.free = append(m.free, f)
+		}
+	}
+	return best.UL, rotated, true
+}
+
+// skylineSeg is a horizontal segment of the skyline, from X to X+W at
+// height Y (measured down from the top of the bin).
+type skylineSeg struct{ x, y, w int }
+
+// skylineBin is a bin packed with Skyline (bottom-left).
+type skylineBin struct {
+	size I2
+	sky  []skylineSeg // sorted by x, covering the width of the bin
+}
+
+// fit returns the Y at which an item of width w would rest if placed at
+// the start of segment i, or false if it would stick out of the bin.
+func (s *skylineBin) fit(i, w, h int) (int, bool) {
+	x := s.sky[i].x
+	if x+w > s.size.X {
+		return 0, false
+	}
+	y := 0
+	for j := i; j < len(s.sky) && s.sky[j].x < x+w; j++ {
+		y = max(y, s.sky[j].y)
+	}
+	return y, y+h <= s.size.Y
+}
+
+func (s *skylineBin) insert(sz I2, rotate bool) (pos I2, rotated, ok bool) {
+	var best I2
+	for i := range s.sky {
+		for k, o := range orientations(sz, rotate) {
+			y, fits := s.fit(i, o.X, o.Y)
+			if !fits {
+				continue
+			}
+			if ok && (y+o.Y > pos.Y+best.Y || (y+o.Y == pos.Y+best.Y && s.sky[i].x >= pos.X)) {
+				continue
+			}
+			pos, best, rotated, ok = I2{s.sky[i].x, y}, o, k == 1, true
+		}
+	}
+	if !ok || best.X == 0 {
+		return pos, rotated, ok
+	}
+
+	// Replace the covered part of the skyline with the top of the item.
+	x0, x1 := pos.X, pos.X+best.X
+	var sky []skylineSeg
+	for _, g := range s.sky {
+		if g.x < x0 {
+			sky = append(sky, skylineSeg{g.x, g.y, min(g.w, x0-g.x)})
+		}
+		if g.x == x0 {
+			sky = append(sky, skylineSeg{x0, pos.Y + best.Y, best.X})
+		}
+		if g.x+g.w > x1 {
+			lo := max(g.x, x1)
+			sky = append(sky, skylineSeg{lo, g.y, g.x + g.w - lo})
+		}
+	}
+	// Merge neighbouring segments at the same height.
+	s.sky = sky[:1]
+	for _, g := range sky[1:] {
+		if last := &s.sky[len(s.sky)-1]; last.y == g.y {
+			last.w += g.w
+			continue
+		}
+		s.sky = append(s.sky, g)
+	}
+	return pos, rotated, true
+}
+
+// guillotineBin is a bin packed with Guillotine (best area fit, splitting
+// along the shorter leftover axis).
+type guillotineBin struct {
+	free []Rect // disjoint free rectangles
+}
+
+func (g *guillotineBin) insert(sz I2, rotate bool) (pos I2, rotated, ok bool) {
+	bestIdx, bestArea, bestShort := -1, 0, 0
+	var best I2
+	for i, f := range g.free {
+		fs := f.Size()
+		for k, s := range orientations(sz, rotate) {
+			if s.X > fs.X || s.Y > fs.Y {
+				continue
+			}
+			area := fs.Area() - s.Area()
+			short := min(fs.X-s.X, fs.Y-s.Y)
+			if bestIdx >= 0 && (area > bestArea || (area == bestArea && short >= bestShort)) {
+				continue
+			}
+			bestIdx, bestArea, bestShort, best, rotated = i, area, short, s, k == 1
+		}
+	}
+	if bestIdx < 0 {
+		return I2{}, false, false
+	}
+	f := g.free[bestIdx]
+	pos = f.UL
+	g.free = slices.Delete(g.free, bestIdx, bestIdx+1)
+
+	// Split the leftover space with a single cut, either giving the full
+	// height to the part on the right or the full width to the part below.
+	d := f.Size().Sub(best)
+	right := Rect{UL: I2{pos.X + best.X, pos.Y}, DR: I2{f.DR.X, pos.Y + best.Y}}
+	below := Rect{UL: I2{pos.X, pos.Y + best.Y}, DR: f.DR}
+	if d.X > d.Y {
+		right.DR.Y = f.DR.Y
+		below.DR.X = pos.X + best.X
+	}
+	for _, r := range []Rect{right, below} {
+		if !r.Empty() {
+			g.free = append(g.free, r)
+		}
+	}
+	return pos, rotated, true
+}
@@ -0,0 +1,150 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+var packAlgorithms = []PackAlgorithm{MaxRects, Skyline, Guillotine}
+
+func TestPackExact(t *testing.T) {
+	tests := []struct {
+		sizes []I2
+		bin   I2
+		pad   int
+		bins  int
+	}{
+		{[]I2{{5, 5}, {5, 5}, {5, 5}, {5, 5}}, I2{10, 10}, 0, 1},
+		{[]I2{{5, 5}, {5, 5}, {5, 5}, {5, 5}}, I2{11, 11}, 1, 1},
+		{[]I2{{5, 5}, {5, 5}, {5, 5}, {5, 5}}, I2{10, 10}, 1, 4},
+		{[]I2{{10, 3}, {10, 3}, {10, 4}}, I2{10, 10}, 0, 1},
+		{[]I2{{8, 8}, {8, 8}, {8, 8}}, I2{8, 8}, 3, 3},
+	}
+	for i, test := range tests {
+		for _, alg := range packAlgorithms {
+			p := Packer{Algorithm: alg, Bin: test.bin, Padding: test.pad}
+			got, err := p.Pack(test.sizes)
+			if err != nil {
+				t.Errorf("Pack test #%d (algorithm %d): got error %v", i, alg, err)
+				continue
+			}
+			checkPacking(t, p, test.sizes, got)
+			bins := 0
+			for _, pl := range got {
+				bins = max(bins, pl.Bin+1)
+			}
+			if bins != test.bins {
+				t.Errorf("Pack test #%d (algorithm %d): got %d bins, want %d", i, alg, bins, test.bins)
+			}
+		}
+	}
+}
+
+func TestPackRotate(t *testing.T) {
+	sizes := []I2{{2, 10}, {10, 2}, {2, 10}}
+	for _, alg := range packAlgorithms {
+		p := Packer{Algorithm: alg, Bin: I2{10, 6}, Rotate: true, MaxBins: 1}
+		got, err := p.Pack(sizes)
+		if err != nil {
+			t.Errorf("Pack (algorithm %d): got error %v", alg, err)
+			continue
+		}
+		checkPacking(t, p, sizes, got)
+		if !got[0].Rotated || got[1].Rotated || !got[2].Rotated {
+			t.Errorf("Pack (algorithm %d): got %v, want first and last rotated", alg, got)
+		}
+		p.Rotate = false
+		if _, err := p.Pack(sizes); err == nil {
+			t.Errorf("Pack (algorithm %d) without rotation: got nil error", alg)
+		}
+	}
+}
+
+func TestPackErrors(t *testing.T) {
+	tests := []struct {
+		p     Packer
+		sizes []I2
+	}{
+		{Packer{Bin: I2{10, 10}}, []I2{{11, 1}}},
+		{Packer{Bin: I2{10, 10}, Rotate: true}, []I2{{11, 11}}},
+		{Packer{Bin: I2{10, 10}}, []I2{{-1, 1}}},
+		{Packer{Bin: I2{10, 10}, MaxBins: 2}, []I2{{6, 6}, {6, 6}, {6, 6}}},
+	}
+	for i, test := range tests {
+		for _, alg := range packAlgorithms {
+			test.p.Algorithm = alg
+			if got, err := test.p.Pack(test.sizes); err == nil {
+				t.Errorf("Pack test #%d (algorithm %d): got %v, nil error; want error", i, alg, got)
+			}
+		}
+	}
+}
+
+func TestPackRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for n := 0; n < 50; n++ {
+		sizes := make([]I2, 1+rng.Intn(60))
+		for i := range sizes {
+			sizes[i] = I2{rng.Intn(30), rng.Intn(30)}
+		}
+		for _, alg := range packAlgorithms {
+			p := Packer{Algorithm: alg, Bin: I2{64, 64}, Padding: rng.Intn(3), Rotate: rng.Intn(2) == 0}
+			got, err := p.Pack(sizes)
+			if err != nil {
+				t.Fatalf("Pack(%v) (algorithm %d): got error %v", sizes, alg, err)
+			}
+			checkPacking(t, p, sizes, got)
+			again, _ := p.Pack(sizes)
+			if !reflect.DeepEqual(got, again) {
+				t.Errorf("Pack(%v) (algorithm %d): not deterministic", sizes, alg)
+			}
+		}
+	}
+}
+
+// checkPacking checks that each placement is in its bin, has the right
+// size, and is padded away from the other items.
+func checkPacking(t *testing.T, p Packer, sizes []I2, got []Placement) {
+	t.Helper()
+	if len(got) != len(sizes) {
+		t.Fatalf("Pack: got %d placements, want %d", len(got), len(sizes))
+	}
+	bin := Rect{DR: p.Bin}
+	pad := I2{p.Padding, p.Padding}
+	for i, pl := range got {
+		want := sizes[i]
+		if pl.Rotated {
+			want = want.Swap()
+		}
+		if pl.Rect.Size() != want {
+			t.Errorf("Pack: item %d has size %v, want %v", i, pl.Rect.Size(), want)
+		}
+		if !pl.Rect.In(bin) {
+			t.Errorf("Pack: item %d at %v is outside the bin %v", i, pl.Rect, bin)
+		}
+		padded := Rect{UL: pl.Rect.UL, DR: pl.Rect.DR.Add(pad)}
+		for j, ql := range got[i+1:] {
+			if ql.Bin != pl.Bin {
+				continue
+			}
+			if padded.Overlaps(Rect{UL: ql.Rect.UL, DR: ql.Rect.DR.Add(pad)}) {
+				t.Errorf("Pack: items %d at %v and %d at %v are too close", i, pl.Rect, i+1+j, ql.Rect)
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import "math"
+
+// The predicates in this file follow Jonathan Shewchuk, "Adaptive Precision
+// Floating-Point Arithmetic and Fast Robust Geometric Predicates" (1997).
+// Each first evaluates the determinant in ordinary floating point, and only
+// if the result is too close to zero to trust its sign does it fall back to
+// exact arithmetic on expansions (sums of non-overlapping float64s).
+// Results are exact unless an intermediate value overflows or underflows.
+
+const (
+	predEpsilon  = 1.0 / (1 << 53)
+	ccwErrBoundA = (3 + 16*predEpsilon) * predEpsilon
+	iccErrBoundA = (10 + 96*predEpsilon) * predEpsilon
+)
+
+// twoSum returns x = fl(a+b) and the roundoff error y, so that a+b = x+y exactly.
+func twoSum(a, b float64) (x, y float64) {
+	x = a + b
+	bv := x - a
+	av := x - bv
+	return x, (a - av) + (b - bv)
+}
+
+// twoProduct returns x = fl(a*b) and the roundoff error y, so that a*b = x+y exactly.
+func twoProduct(a, b float64) (x, y float64) {
+	x = a * b
+	return x, math.FMA(a, b, -x)
+}
+
+// expansion is a sum of non-overlapping float64s, in increasing order of
+// magnitude, with no zero components.
+type expansion []float64
+
+// exactDiff returns a-b exactly.
+func exactDiff(a, b float64) expansion {
+	x, y := twoSum(a, -b)
+	return expansion{}.grow(y).grow(x)
+}
+
+// grow returns e+b (Shewchuk's Grow-Expansion with zero elimination).
+func (e expansion) grow(b float64) expansion {
+	h := make(expansion, 0, len(e)+1)
+	q := b
+	for _, ei := range e {
+		var hh float64
+		q, hh = twoSum(q, ei)
+		if hh != 0 {
+			h = append(h, hh)
+		}
+	}
+	if q != 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// add returns e+f.
+func (e expansion) add(f expansion) expansion {
+	for _, fi := range f {
+		e = e.grow(fi)
+	}
+	return e
+}
+
+// neg returns -e.
+func (e expansion) neg() expansion {
+	h := make(expansion, len(e))
+	for i, ei := range e {
+		h[i] = -ei
+	}
+	return h
+}
+
+// scale returns e*b.
+func (e expansion) scale(b float64) expansion {
+	var h expansion
+	for _, ei := range e {
+		p, err := twoProduct(ei, b)
+		h = h.grow(err).grow(p)
+	}
+	return h
+}
+
+// mul returns e*f.
+func (e expansion) mul(f expansion) expansion {
+	var h expansion
+	for _, fi := range f {
+		h = h.add(e.scale(fi))
+	}
+	return h
+}
+
+// estimate returns an approximation of e with the correct sign.
+func (e expansion) estimate() float64 {
+	// The components don't overlap, so the largest dominates the sum.
+	var s float64
+	for _, ei := range e {
+		s += ei
+	}
+	return s
+}
+
+// cross returns the 2D cross product (q-p) x (b-a), or an approximation
+// of it with the correct sign. It is zero exactly when q-p and b-a are
+// parallel (or either is zero).
+func cross(p, q, a, b F2) float64 {
+	l := (q.X - p.X) * (b.Y - a.Y)
+	r := (q.Y - p.Y) * (b.X - a.X)
+	det := l - r
+	if bound := ccwErrBoundA * (math.Abs(l) + math.Abs(r)); det > bound || -det > bound {
+		return det
+	}
+	qpx, qpy := exactDiff(q.X, p.X), exactDiff(q.Y, p.Y)
+	bax, bay := exactDiff(b.X, a.X), exactDiff(b.Y, a.Y)
+	return qpx.mul(bay).add(qpy.mul(bax).neg()).estimate()
+}
+
+// Orient2D returns a positive value if a, b, c occur in counterclockwise
+// order (in the same sense as SignedArea2), a negative value if they are
+// in clockwise order, and zero if they are collinear. The result
+// approximates double the signed area of the triangle abc, and its sign
+// is always correct.
+func Orient2D(a, b, c F2) float64 { return cross(a, b, a, c) }
+
+// InCircle returns a positive value if d lies inside the circle passing
+// through a, b and c, a negative value if it lies outside, and zero if the
+// four points are cocircular. a, b and c must be in counterclockwise order
+// (see Orient2D), otherwise the sign is reversed. The sign of the result is
+// always correct.
+func InCircle(a, b, c, d F2) float64 {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	alift := adx*adx + ady*ady
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	blift := bdx*bdx + bdy*bdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	clift := cdx*cdx + cdy*cdy
+
+	det := alift*(bdxcdy-cdxbdy) + blift*(cdxady-adxcdy) + clift*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*alift +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*blift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*clift
+	if bound := iccErrBoundA * permanent; det > bound || -det > bound {
+		return det
+	}
+
+	eadx, eady := exactDiff(a.X, d.X), exactDiff(a.Y, d.Y)
+	ebdx, ebdy := exactDiff(b.X, d.X), exactDiff(b.Y, d.Y)
+	ecdx, ecdy := exactDiff(c.X, d.X), exactDiff(c.Y, d.Y)
+	ealift := eadx.mul(eadx).add(eady.mul(eady))
+	eblift := ebdx.mul(ebdx).add(ebdy.mul(ebdy))
+	eclift := ecdx.mul(ecdx).add(ecdy.mul(ecdy))
+	bc := ebdx.mul(ecdy).add(ecdx.mul(ebdy).neg())
+	ca := ecdx.mul(eady).add(eadx.mul(ecdy).neg())
+	ab := eadx.mul(ebdy).add(ebdx.mul(eady).neg())
+	return ealift.mul(bc).add(eblift.mul(ca)).add(eclift.mul(ab)).estimate()
+}
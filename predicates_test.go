@@ -0,0 +1,71 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func sign(x float64) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func TestOrient2D(t *testing.T) {
+	tests := []struct {
+		a, b, c F2
+		want    int
+	}{
+		{F2{0, 0}, F2{1, 0}, F2{0, 1}, 1},
+		{F2{0, 0}, F2{0, 1}, F2{1, 0}, -1},
+		{F2{0, 0}, F2{1, 1}, F2{2, 2}, 0},
+		{F2{0.1, 0.1}, F2{0.3, 0.3}, F2{0.7, 0.7}, 0}, // Inexact inputs, but still collinear.
+		{F2{12, 12}, F2{24, 24}, F2{0.5, math.Nextafter(0.5, 1)}, 1},
+		{F2{12, 12}, F2{24, 24}, F2{0.5, math.Nextafter(0.5, 0)}, -1},
+		{F2{1e-300, 0}, F2{0, 1e-300}, F2{-1e-300, 2e-300}, 0},
+		{F2{1e150, 0}, F2{0, 1e150}, F2{-1e150, 2e150}, 0},
+	}
+	for i, test := range tests {
+		if got := sign(Orient2D(test.a, test.b, test.c)); got != test.want {
+			t.Errorf("Orient2D test #%d: got sign %d, want %d", i, got, test.want)
+		}
+	}
+}
+
+func TestInCircle(t *testing.T) {
+	a, b, c := F2{1, 0}, F2{0, 1}, F2{-1, 0}
+	tests := []struct {
+		d    F2
+		want int
+	}{
+		{F2{0, 0}, 1},
+		{F2{0, -1}, 0},
+		{F2{0, 2}, -1},
+		{F2{0, math.Nextafter(-1, 0)}, 1},
+		{F2{0, math.Nextafter(-1, -2)}, -1},
+	}
+	for i, test := range tests {
+		if got := sign(InCircle(a, b, c, test.d)); got != test.want {
+			t.Errorf("InCircle test #%d: got sign %d, want %d", i, got, test.want)
+		}
+	}
+}
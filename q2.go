@@ -0,0 +1,264 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Q32 is a Q32.32 fixed-point number: a signed 64-bit integer with 32
+// fractional bits. All arithmetic on Q32 and Q2 uses only integer
+// operations, so results are bit-identical on every platform.
+type Q32 int64
+
+// Useful Q32 constants.
+const (
+	Q32One    = Q32(1 << 32)
+	Q32Pi     = Q32(13493037705)
+	Q32TwoPi  = Q32(26986075409)
+	Q32HalfPi = Q32(6746518852)
+)
+
+// Q32FromInt converts an int to a Q32.
+func Q32FromInt(n int) Q32 { return Q32(n) << 32 }
+
+// Q32FromFloat converts a float64 to the nearest Q32. Since it starts from a
+// float, it should only be used for setting up initial conditions, not in
+// the middle of a simulation.
+func Q32FromFloat(f float64) Q32 { return Q32(math.Round(f * (1 << 32))) }
+
+// Float converts x to a float64, which may potentially cause loss of precision.
+func (x Q32) Float() float64 { return float64(x) / (1 << 32) }
+
+// Int returns the largest integer not greater than x.
+func (x Q32) Int() int { return int(x >> 32) }
+
+// Abs returns the absolute value of x.
+func (x Q32) Abs() Q32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Mul returns x * y, rounded to the nearest Q32.
+func (x Q32) Mul(y Q32) Q32 {
+	neg := (x < 0) != (y < 0)
+	hi, lo := bits.Mul64(uint64(x.Abs()), uint64(y.Abs()))
+	lo, c := bits.Add64(lo, 1<<31, 0)
+	hi += c
+	r := Q32(hi<<32 | lo>>32)
+	if neg {
+		return -r
+	}
+	return r
+}
+
+// Div returns x / y, truncated towards zero. Like integer division, it
+// panics if y is zero or the quotient overflows.
+func (x Q32) Div(y Q32) Q32 {
+	neg := (x < 0) != (y < 0)
+	ux := uint64(x.Abs())
+	q, _ := bits.Div64(ux>>32, ux<<32, uint64(y.Abs()))
+	if neg {
+		return -Q32(q)
+	}
+	return Q32(q)
+}
+
+// isqrt128 returns the largest r such that r*r <= hi<<64 | lo.
+func isqrt128(hi, lo uint64) uint64 {
+	var r uint64
+	for b := 63; b >= 0; b-- {
+		c := r | 1<<uint(b)
+		chi, clo := bits.Mul64(c, c)
+		if chi < hi || (chi == hi && clo <= lo) {
+			r = c
+		}
+	}
+	return r
+}
+
+// Sqrt returns the square root of x, truncated to a Q32. It returns 0 for
+// negative x.
+func (x Q32) Sqrt() Q32 {
+	if x <= 0 {
+		return 0
+	}
+	return Q32(isqrt128(uint64(x)>>32, uint64(x)<<32))
+}
+
+// sinQuarter is the number of sinTable steps in a quarter turn.
+const sinQuarter = 1024
+
+// sinTable holds sin(2πk/(4*sinQuarter)) for k in [0, 4*sinQuarter].
+var sinTable = func() (t [4*sinQuarter + 1]Q32) {
+	for k := 0; k <= sinQuarter; k++ {
+		// Taylor series, in Q32 arithmetic so the table is the same everywhere.
+		x := Q32HalfPi * Q32(k) / sinQuarter
+		x2 := x.Mul(x)
+		term, sum := x, x
+		for n := 2; term != 0; n += 2 {
+			term = -term.Mul(x2) / Q32(n*(n+1))
+			sum += term
+		}
+		t[k] = sum
+		t[2*sinQuarter-k] = sum
+		t[2*sinQuarter+k] = -sum
+		t[4*sinQuarter-k] = -sum
+	}
+	return
+}()
+
+// Sin returns the sine of x radians, using a lookup table with linear
+// interpolation. The result is accurate to about 1e-6.
+func (x Q32) Sin() Q32 {
+	x %= Q32TwoPi
+	if x < 0 {
+		x += Q32TwoPi
+	}
+	// Find which table step x falls in, and how far along it.
+	hi, lo := bits.Mul64(uint64(x), 4*sinQuarter)
+	k, rem := bits.Div64(hi, lo, uint64(Q32TwoPi))
+	frac, _ := bits.Div64(rem>>32, rem<<32, uint64(Q32TwoPi))
+	return sinTable[k] + (sinTable[k+1] - sinTable[k]).Mul(Q32(frac))
+}
+
+// Cos returns the cosine of x radians. See Sin.
+func (x Q32) Cos() Q32 { return (x%Q32TwoPi + Q32HalfPi).Sin() }
+
+// Q2 is a pair of Q32 fixed-point numbers, (X,Y). It is intended for
+// deterministic (lockstep) simulations, where F2 results can differ across
+// architectures.
+type Q2 struct{ X, Y Q32 }
+
+// NewQ2 is a convenience function for creating a Q2.
+func NewQ2(x, y Q32) Q2 { return Q2{x, y} }
+
+// Q2FromI2 converts an I2 to a Q2.
+func Q2FromI2(v I2) Q2 { return Q2{Q32FromInt(v.X), Q32FromInt(v.Y)} }
+
+// Q2FromF2 converts an F2 to the nearest Q2. See Q32FromFloat.
+func Q2FromF2(v F2) Q2 { return Q2{Q32FromFloat(v.X), Q32FromFloat(v.Y)} }
+
+// C returns the components of v (X,Y).
+func (v Q2) C() (Q32, Q32) { return v.X, v.Y }
+
+// F2 converts this Q2 to an F2, which may potentially cause loss of precision.
+func (v Q2) F2() F2 { return F2{v.X.Float(), v.Y.Float()} }
+
+// I2 converts this Q2 to an I2, rounding each component down.
+func (v Q2) I2() I2 { return I2{v.X.Int(), v.Y.Int()} }
+
+// Add returns v + w.
+func (v Q2) Add(w Q2) Q2 { return Q2{v.X + w.X, v.Y + w.Y} }
+
+// Sub returns v - w.
+func (v Q2) Sub(w Q2) Q2 { return Q2{v.X - w.X, v.Y - w.Y} }
+
+// Mul returns the scalar product k * v.
+func (v Q2) Mul(k Q32) Q2 { return Q2{v.X.Mul(k), v.Y.Mul(k)} }
+
+// Div returns the componentwise division by k.
+func (v Q2) Div(k Q32) Q2 { return Q2{v.X.Div(k), v.Y.Div(k)} }
+
+// Dot returns the dot product, v dot w.
+func (v Q2) Dot(w Q2) Q32 { return v.X.Mul(w.X) + v.Y.Mul(w.Y) }
+
+// Norm returns the length of v (the square root of v dot v). The sum of
+// squares is computed in 128 bits, so this does not overflow for any v
+// whose length is representable.
+func (v Q2) Norm() Q32 {
+	x, y := uint64(v.X.Abs()), uint64(v.Y.Abs())
+	xh, xl := bits.Mul64(x, x)
+	yh, yl := bits.Mul64(y, y)
+	lo, c := bits.Add64(xl, yl, 0)
+	return Q32(isqrt128(xh+yh+c, lo))
+}
+
+// Unit returns the unit vector pointing in the same direction as v. The
+// zero vector has no direction, so its Unit is the zero vector.
+func (v Q2) Unit() Q2 {
+	n := v.Norm()
+	if n == 0 {
+		return Q2{}
+	}
+	return v.Div(n)
+}
+
+// Normal returns a vector perpendicular to v of the same length.
+func (v Q2) Normal() Q2 { return Q2{-v.Y, v.X} }
+
+// Cmul returns the complex product v * w, where the X components are treated as real
+// and the Y components as imaginary.
+func (v Q2) Cmul(w Q2) Q2 {
+	return Q2{w.X.Mul(v.X) - w.Y.Mul(v.Y), w.Y.Mul(v.X) + w.X.Mul(v.Y)}
+}
+
+// Rot rotates the vector by the angle t (in radians).
+func (v Q2) Rot(t Q32) Q2 { return v.Cmul(Q2{t.Cos(), t.Sin()}) }
+
+// RotAbout rotates the vector by the angle t around the vector b.
+func (v Q2) RotAbout(t Q32, b Q2) Q2 { return v.Sub(b).Rot(t).Add(b) }
+
+// divFits reports whether num.Div(det) fits in a Q32 (without panicking or
+// changing sign).
+func divFits(num, det Q32) bool { return num.Abs()>>31 < det.Abs() }
+
+// LineIntersectQ finds the intersection of the lines (infinite) through p,q and a,b,
+// or returns false if they are parallel, or so nearly parallel that the
+// intersection is too far away to represent.
+func LineIntersectQ(p, q, a, b Q2) (Q2, bool) {
+	dx1, dx2, dy1, dy2 := p.X-q.X, a.X-b.X, p.Y-q.Y, a.Y-b.Y
+	det := dx2.Mul(dy1) - dx1.Mul(dy2)
+	if det == 0 {
+		return Q2{}, false
+	}
+	pq := q.X.Mul(p.Y) - p.X.Mul(q.Y)
+	ab := b.X.Mul(a.Y) - a.X.Mul(b.Y)
+	n := Q2{pq.Mul(dx2) - ab.Mul(dx1), pq.Mul(dy2) - ab.Mul(dy1)}
+	if !divFits(n.X, det) || !divFits(n.Y, det) {
+		return Q2{}, false
+	}
+	return n.Div(det), true
+}
+
+// inUnit reports whether num/det is in [0, 1), without dividing.
+func inUnit(num, det Q32) bool {
+	return num == 0 || ((num < 0) == (det < 0) && num.Abs() < det.Abs())
+}
+
+// SegmentIntersectQ tests for the intersection of the line segments p-q, a-b;
+// if there is an intersection it returns how far along a-b the
+// intersection occurs.
+func SegmentIntersectQ(p, q, a, b Q2) (Q32, bool) {
+	qmpn, bma := q.Sub(p).Normal(), b.Sub(a)
+	det := bma.Dot(qmpn)
+	if det == 0 {
+		return 0, false
+	}
+	// Is our result (t,t') is within bounds? Check before dividing, since
+	// the quotients can overflow when the segments are nearly parallel.
+	pma := p.Sub(a)
+	if !inUnit(pma.Dot(bma.Normal()), det) {
+		return 0, false
+	}
+	num := pma.Dot(qmpn)
+	if !inUnit(num, det) {
+		return 0, false
+	}
+	return num.Div(det), true
+}
@@ -0,0 +1,135 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQ32Arithmetic(t *testing.T) {
+	three, four := Q32FromInt(3), Q32FromInt(4)
+	if got, want := three.Mul(four), Q32FromInt(12); got != want {
+		t.Errorf("Mul: got %v want %v", got, want)
+	}
+	if got, want := (-three).Mul(Q32One/2), Q32FromFloat(-1.5); got != want {
+		t.Errorf("Mul: got %v want %v", got, want)
+	}
+	if got, want := three.Div(-four), Q32FromFloat(-0.75); got != want {
+		t.Errorf("Div: got %v want %v", got, want)
+	}
+	if got, want := Q32FromInt(16).Sqrt(), four; got != want {
+		t.Errorf("Sqrt: got %v want %v", got, want)
+	}
+	if got, want := (Q2{three, -four}).Norm(), Q32FromInt(5); got != want {
+		t.Errorf("Norm: got %v want %v", got, want)
+	}
+	if got, want := (Q2{three, -four}).Unit(), (Q2{Q32FromFloat(0.6), Q32FromFloat(-0.8)}); got.Sub(want).Norm() > 2 {
+		t.Errorf("Unit: got %v want %v", got, want)
+	}
+	if got, want := (Q2{}).Unit(), (Q2{}); got != want {
+		t.Errorf("Unit (zero): got %v want %v", got, want)
+	}
+	if got, want := Q32FromFloat(-0.5).Int(), -1; got != want {
+		t.Errorf("Int: got %d want %d", got, want)
+	}
+}
+
+func TestQ32SinCos(t *testing.T) {
+	for x := -10.0; x < 10; x += 0.01 {
+		q := Q32FromFloat(x)
+		if got, want := q.Sin().Float(), math.Sin(x); math.Abs(got-want) > 1e-6 {
+			t.Errorf("Sin(%f): got %f want %f", x, got, want)
+		}
+		if got, want := q.Cos().Float(), math.Cos(x); math.Abs(got-want) > 1e-6 {
+			t.Errorf("Cos(%f): got %f want %f", x, got, want)
+		}
+	}
+	// The exact bits should be the same on every platform.
+	if got, want := Q32One.Sin(), Q32(3614089973); got != want {
+		t.Errorf("Sin(1): got %d want %d", got, want)
+	}
+	if got, want := (Q2{Q32One, 0}).Rot(Q32HalfPi).F2(), (F2{0, 1}); !near(got, want) {
+		t.Errorf("Rot: got %v want %v", got, want)
+	}
+}
+
+func TestSegmentIntersectQ(t *testing.T) {
+	tests := []struct {
+		p, q, a, b F2
+		want       bool
+		wantT      float64
+	}{
+		{F2{0, 0}, F2{1, 1}, F2{0, 1}, F2{1, 0}, true, 0.5},
+		{F2{0, 1}, F2{1, 0}, F2{0, 0}, F2{1, 1}, true, 0.5},
+		{F2{0, 0}, F2{1, 1}, F2{0, 0}, F2{0, 1}, true, 0.0},
+		{F2{0, 0}, F2{1, 1}, F2{1, 0}, F2{1, 1}, false, 0.0}, // Start of line is included, not end.
+		{F2{-1000, 0}, F2{1000, 0}, F2{-10, -10}, F2{10, 10}, true, 0.5},
+		{F2{-1, 0}, F2{1, 0}, F2{-1, -1}, F2{1, -1}, false, 0.0}, // Parallel
+		{F2{-1, -1}, F2{1, 1}, F2{0, 3}, F2{3, 0}, false, 0.0},   // a-b beyond p-q.
+	}
+
+	for i, test := range tests {
+		gotT, got := SegmentIntersectQ(Q2FromF2(test.p), Q2FromF2(test.q), Q2FromF2(test.a), Q2FromF2(test.b))
+		if got != test.want {
+			t.Errorf("SegmentIntersectQ test #%d: got %t, want %t", i, got, test.want)
+		}
+		if math.Abs(gotT.Float()-test.wantT) > 0.00001 {
+			t.Errorf("SegmentIntersectQ test #%d: got t=%f, want %f", i, gotT.Float(), test.wantT)
+		}
+	}
+}
+
+func TestIntersectQNearlyParallel(t *testing.T) {
+	const eps = Q32(1) // 2^-32
+	one := Q32One
+	tests := []struct {
+		p, q, a, b Q2
+		seg        bool
+		segT       Q32
+		line       bool
+	}{
+		// Parallel to within eps, and far apart: no intersection anywhere
+		// representable.
+		{Q2{0, 0}, Q2{one, 0}, Q2{0, one}, Q2{one, one + eps}, false, 0, false},
+		{Q2{0, 0}, Q2{one, 0}, Q2{0, -one}, Q2{one, -one - eps}, false, 0, false},
+		// Nearly parallel, but crossing halfway along.
+		{Q2{0, 0}, Q2{one, 0}, Q2{0, -eps}, Q2{one, eps}, true, one / 2, true},
+		// Nearly parallel, crossing just before the start of a-b.
+		{Q2{0, 0}, Q2{one, 0}, Q2{0, eps}, Q2{one, 3 * eps}, false, 0, true},
+	}
+	for i, test := range tests {
+		gotT, got := SegmentIntersectQ(test.p, test.q, test.a, test.b)
+		if got != test.seg || gotT != test.segT {
+			t.Errorf("SegmentIntersectQ test #%d: got (%v, %t), want (%v, %t)", i, gotT, got, test.segT, test.seg)
+		}
+		if _, got := LineIntersectQ(test.p, test.q, test.a, test.b); got != test.line {
+			t.Errorf("LineIntersectQ test #%d: got %t, want %t", i, got, test.line)
+		}
+	}
+}
+
+func TestLineIntersectQ(t *testing.T) {
+	p, ok := LineIntersectQ(Q2FromI2(I2{0, 0}), Q2FromI2(I2{2, 2}), Q2FromI2(I2{0, 2}), Q2FromI2(I2{2, 0}))
+	if !ok {
+		t.Fatalf("LineIntersectQ: got false, want true")
+	}
+	if got, want := p, Q2FromI2(I2{1, 1}); got != want {
+		t.Errorf("LineIntersectQ: got %v want %v", got, want)
+	}
+	if _, ok := LineIntersectQ(Q2FromI2(I2{0, 0}), Q2FromI2(I2{1, 0}), Q2FromI2(I2{0, 1}), Q2FromI2(I2{1, 1})); ok {
+		t.Errorf("LineIntersectQ parallel: got true, want false")
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// FillRule decides which points are inside a self-intersecting or
+// nested polygon.
+type FillRule int
+
+// FillRule values.
+const (
+	// EvenOdd counts a point as inside if a ray from it crosses the
+	// boundary an odd number of times.
+	EvenOdd = FillRule(iota)
+	// NonZero counts a point as inside if the boundary winds around it a
+	// non-zero number of times.
+	NonZero
+)
+
+// inside reports whether the winding number w is inside under the rule.
+func (f FillRule) inside(w int) bool {
+	if f == NonZero {
+		return w != 0
+	}
+	return w&1 != 0
+}
+
+// Raster rasterises shapes into grid cells, where each cell is the unit
+// square centred on an integer point (as with SegmentCells). Cells are
+// produced in row-major order, each at most once, and only within Clip.
+// Note that the zero Raster has an empty Clip, and so produces nothing.
+type Raster struct {
+	Clip Rect     // only cells within Clip are produced
+	Rule FillRule // fill rule for polygons; convex shapes are the same under either
+	// If Conservative is set, every cell the shape touches (even at
+	// a single point) is produced, rather than only the cells whose
+	// centres are inside or on the boundary.
+	Conservative bool
+}
+
+// span is an inclusive range of X values within a row.
+type span struct{ lo, hi int }
+
+// rows returns an iterator over the cells in rows y0 to y1 inclusive,
+// using row to find the spans of each row.
+func (r Raster) rows(y0, y1 int, row func(y int, s []span) []span) iter.Seq[I2] {
+	return func(yield func(I2) bool) {
+		y0, y1 = max(y0, r.Clip.UL.Y), min(y1, r.Clip.DR.Y-1)
+		var s []span
+		for y := y0; y <= y1; y++ {
+			s = row(y, s[:0])
+			slices.SortFunc(s, func(a, b span) int { return cmp.Compare(a.lo, b.lo) })
+			end := r.Clip.UL.X
+			for _, sp := range s {
+				lo, hi := max(sp.lo, end), min(sp.hi, r.Clip.DR.X-1)
+				for x := lo; x <= hi; x++ {
+					if !yield(I2{x, y}) {
+						return
+					}
+				}
+				end = max(end, hi+1)
+			}
+		}
+	}
+}
+
+// isqrt returns the largest r such that r*r <= n, for n >= 0.
+func isqrt(n int64) int64 { return int64(isqrt128(0, uint64(n))) }
+
+// ellipseAxes returns the doubled semi-axes of the ellipse with semi-axes
+// rx+½, ry+½ and their squares.
+func ellipseAxes(rx, ry int) (a2, b2 int64) {
+	a, b := int64(2*rx+1), int64(2*ry+1)
+	return a * a, b * b
+}
+
+// ellipseWidth returns the largest doubled X offset at the doubled Y
+// offset y that is within the ellipse whose doubled semi-axes have squares
+// a2 and b2, or -1 if there is none.
+func ellipseWidth(a2, b2, y int64) int64 {
+	r := a2*b2 - y*y*a2
+	if r < 0 {
+		return -1
+	}
+	return isqrt(r / b2)
+}
+
+// cornerWidth returns the smallest X offset of a cell whose farthest
+// corner is not strictly inside the ellipse, given the doubled Y offset y
+// of that corner.
+func cornerWidth(a2, b2, y int64) int {
+	r := a2*b2 - y*y*a2
+	if r <= 0 {
+		return 0
+	}
+	// The smallest t with t*t*b2 >= r, then the smallest x with 2x+1 >= t.
+	t := isqrt((r+b2-1)/b2-1) + 1
+	return int(t / 2)
+}
+
+// halfWidth returns the largest X offset of a cell in row offset y of the
+// ellipse, or -1 if there are none.
+func (r Raster) halfWidth(a2, b2 int64, y int) int {
+	y = Abs(y)
+	if r.Conservative {
+		// The nearest point of the cell to the centre is half a cell closer.
+		w := ellipseWidth(a2, b2, int64(max(2*y-1, 0)))
+		if w < 0 {
+			return -1
+		}
+		return int((w + 1) / 2)
+	}
+	w := ellipseWidth(a2, b2, int64(2*y))
+	if w < 0 {
+		return -1
+	}
+	return int(w / 2)
+}
+
+// Ellipse returns an iterator over the cells of the filled ellipse centred
+// on the cell c with semi-axes rx+½ and ry+½ (so that the extreme cells
+// in each direction are rx and ry cells from c). Without Conservative,
+// this is the area bounded by the midpoint ellipse. rx and ry should be
+// less than 2^14.
+func (r Raster) Ellipse(c I2, rx, ry int) iter.Seq[I2] {
+	a2, b2 := ellipseAxes(rx, ry)
+	h := ry
+	if r.Conservative {
+		h++
+	}
+	return r.rows(c.Y-h, c.Y+h, func(y int, s []span) []span {
+		if w := r.halfWidth(a2, b2, y-c.Y); w >= 0 {
+			s = append(s, span{c.X - w, c.X + w})
+		}
+		return s
+	})
+}
+
+// EllipseOutline returns an iterator over the cells on the boundary of the
+// ellipse (see Ellipse). Without Conservative, these are the cells of the
+// filled ellipse that have an edge-adjacent neighbour outside it, which
+// form an 8-connected outline. With Conservative, these are all the cells
+// the boundary curve touches.
+func (r Raster) EllipseOutline(c I2, rx, ry int) iter.Seq[I2] {
+	a2, b2 := ellipseAxes(rx, ry)
+	h := ry
+	if r.Conservative {
+		h++
+	}
+	return r.rows(c.Y-h, c.Y+h, func(y int, s []span) []span {
+		dy := Abs(y - c.Y)
+		hi := r.halfWidth(a2, b2, dy)
+		if hi < 0 {
+			return s
+		}
+		var lo int
+		if r.Conservative {
+			lo = min(hi, cornerWidth(a2, b2, int64(2*dy+1)))
+		} else {
+			lo = min(hi, r.halfWidth(a2, b2, dy+1)+1)
+		}
+		s = append(s, span{c.X - hi, c.X - lo}, span{c.X + lo, c.X + hi})
+		return s
+	})
+}
+
+// Circle returns an iterator over the cells of the filled circle centred on
+// the cell c with radius radius+½ (see Ellipse).
+func (r Raster) Circle(c I2, radius int) iter.Seq[I2] { return r.Ellipse(c, radius, radius) }
+
+// CircleOutline returns an iterator over the cells on the boundary of the
+// circle (see EllipseOutline). Without Conservative, this is a thin
+// 8-connected outline like that of the midpoint circle algorithm.
+func (r Raster) CircleOutline(c I2, radius int) iter.Seq[I2] {
+	return r.EllipseOutline(c, radius, radius)
+}
+
+// crossing is where a polygon edge crosses a row: at X = num/den, going
+// up (dir = 1) or down (dir = -1).
+type crossing struct {
+	num, den int64
+	dir      int
+}
+
+// cmpCrossing orders crossings by X. It compares the integer parts first,
+// so that the cross-multiplied remainders can't overflow.
+func cmpCrossing(p, q crossing) int {
+	pi, pr := floorDivMod(p.num, p.den)
+	qi, qr := floorDivMod(q.num, q.den)
+	return cmp.Or(cmp.Compare(pi, qi), cmp.Compare(pr*q.den, qr*p.den))
+}
+
+// floorDivMod returns n/d rounded down and the remainder, for d > 0.
+func floorDivMod(n, d int64) (q, r int64) {
+	q, r = n/d, n%d
+	if r < 0 {
+		q, r = q-1, r+d
+	}
+	return q, r
+}
+
+// Polygon returns an iterator over the cells of the filled polygon with
+// the given vertices, using Rule to decide which points are inside. The
+// polygon is closed (the last vertex joins the first) and includes its
+// boundary. Without Conservative, a cell is produced if its centre is
+// inside the polygon or on an edge; with Conservative, if any point of
+// the cell is. The work done is proportional to the number of edges times
+// the number of rows of Clip, plus the number of cells produced, however
+// much of the polygon lies outside Clip.
+func (r Raster) Polygon(poly []I2) iter.Seq[I2] {
+	if len(poly) == 0 {
+		return func(func(I2) bool) {}
+	}
+	// Only the edges reaching the rows of Clip matter, both for the
+	// boundary and for the winding number.
+	var edges []Edge
+	y0, y1 := poly[0].Y, poly[0].Y
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		y0, y1 = min(y0, a.Y), max(y1, a.Y)
+		if max(a.Y, b.Y) >= r.Clip.UL.Y && min(a.Y, b.Y) < r.Clip.DR.Y {
+			edges = append(edges, Edge{a, b})
+		}
+	}
+
+	return r.rows(y0, y1, func(y int, s []span) []span {
+		// Cells on the boundary, and the crossings for the cells whose
+		// centres are strictly inside. Each edge covers the rows
+		// [min, max) so that vertices aren't counted twice.
+		var xs []crossing
+		for _, e := range edges {
+			if sp, ok := r.edgeSpan(e.U, e.V, y); ok {
+				s = append(s, sp)
+			}
+			a, b := e.U, e.V
+			c := crossing{dir: 1}
+			if a.Y > b.Y {
+				a, b, c.dir = b, a, -1
+			}
+			if y < a.Y || y >= b.Y {
+				continue
+			}
+			c.den = int64(b.Y - a.Y)
+			c.num = int64(a.X)*c.den + int64(y-a.Y)*int64(b.X-a.X)
+			xs = append(xs, c)
+		}
+		slices.SortFunc(xs, cmpCrossing)
+		w := 0
+		for i := 0; i+1 < len(xs); i++ {
+			w += xs[i].dir
+			if !r.Rule.inside(w) {
+				continue
+			}
+			lo := ceilDiv(int(xs[i].num), int(xs[i].den))
+			hi := divDown(int(xs[i+1].num), int(xs[i+1].den))
+			if lo <= hi {
+				s = append(s, span{lo, hi})
+			}
+		}
+		return s
+	})
+}
+
+// edgeSpan returns the cells in row y on the polygon edge a-b: the cells
+// whose centres are on it, or with Conservative, the cells it touches (as
+// SegmentCells with Supercover).
+func (r Raster) edgeSpan(a, b I2, y int) (span, bool) {
+	if a.Y > b.Y {
+		a, b = b, a
+	}
+	if y < a.Y || y > b.Y {
+		return span{}, false
+	}
+	dy, dx := int64(b.Y-a.Y), int64(b.X-a.X)
+	if dy == 0 {
+		return span{min(a.X, b.X), max(a.X, b.X)}, true
+	}
+	if !r.Conservative {
+		// At most one lattice point of a-b is in each row.
+		n := int64(y-a.Y) * dx
+		if n%dy != 0 {
+			return span{}, false
+		}
+		x := a.X + int(n/dy)
+		return span{x, x}, true
+	}
+	// The X coordinates (in units of 1/2dy) where a-b enters and leaves
+	// the strip from y-½ to y+½, then the cells overlapping that range.
+	x := func(y2 int64) int64 { return 2*int64(a.X)*dy + (y2-2*int64(a.Y))*dx }
+	p := x(max(2*int64(y)-1, 2*int64(a.Y)))
+	q := x(min(2*int64(y)+1, 2*int64(b.Y)))
+	p, q = min(p, q), max(p, q)
+	return span{ceilDiv(int(p-dy), int(2*dy)), divDown(int(q+dy), int(2*dy))}, true
+}
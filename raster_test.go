@@ -0,0 +1,178 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"iter"
+	"math/bits"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// collectCells collects seq, checking that it is in row-major order
+// without repeats.
+func collectCells(t *testing.T, name string, seq iter.Seq[I2]) map[I2]bool {
+	t.Helper()
+	set := make(map[I2]bool)
+	var prev *I2
+	for p := range seq {
+		if prev != nil && cmpI2(*prev, p) >= 0 {
+			t.Errorf("%s: %v after %v, want row-major order without repeats", name, p, *prev)
+		}
+		set[p] = true
+		prev = &p
+	}
+	return set
+}
+
+func TestRasterCircle(t *testing.T) {
+	tests := []struct {
+		r    int
+		cons bool
+		want []I2
+	}{
+		{0, false, []I2{{5, 5}}},
+		{0, true, []I2{{5, 4}, {4, 5}, {5, 5}, {6, 5}, {5, 6}}},
+		{1, false, []I2{{4, 4}, {5, 4}, {6, 4}, {4, 5}, {5, 5}, {6, 5}, {4, 6}, {5, 6}, {6, 6}}},
+	}
+	for i, test := range tests {
+		r := Raster{Clip: NewRect(0, 0, 10, 10), Conservative: test.cons}
+		if got := slices.Collect(r.Circle(I2{5, 5}, test.r)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Circle test #%d: got %v, want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestRasterEllipseBruteForce(t *testing.T) {
+	clip := NewRect(-12, -12, 12, 12)
+	for _, rx := range []int{0, 1, 2, 5, 9} {
+		for _, ry := range []int{0, 1, 3, 7} {
+			a2, b2 := ellipseAxes(rx, ry)
+			// in reports whether the doubled offset (x, y) is in the ellipse.
+			in := func(x, y int) bool { return int64(x*x)*b2+int64(y*y)*a2 <= a2*b2 }
+			strictlyIn := func(x, y int) bool { return int64(x*x)*b2+int64(y*y)*a2 < a2*b2 }
+			near := func(v int) int { return max(2*Abs(v)-1, 0) }
+			for _, cons := range []bool{false, true} {
+				r := Raster{Clip: clip, Conservative: cons}
+				fill := collectCells(t, "Ellipse", r.Ellipse(I2{}, rx, ry))
+				outline := collectCells(t, "EllipseOutline", r.EllipseOutline(I2{}, rx, ry))
+				for p := range clip.Points(RowMajor) {
+					var wantFill, wantOutline bool
+					if cons {
+						wantFill = in(near(p.X), near(p.Y))
+						wantOutline = wantFill && !strictlyIn(2*Abs(p.X)+1, 2*Abs(p.Y)+1)
+					} else {
+						wantFill = in(2*p.X, 2*p.Y)
+						wantOutline = wantFill && !(in(2*p.X-2, 2*p.Y) && in(2*p.X+2, 2*p.Y) &&
+							in(2*p.X, 2*p.Y-2) && in(2*p.X, 2*p.Y+2))
+					}
+					if fill[p] != wantFill {
+						t.Errorf("Ellipse(%d, %d, conservative=%t) at %v: got %t, want %t", rx, ry, cons, p, fill[p], wantFill)
+					}
+					if outline[p] != wantOutline {
+						t.Errorf("EllipseOutline(%d, %d, conservative=%t) at %v: got %t, want %t", rx, ry, cons, p, outline[p], wantOutline)
+					}
+				}
+			}
+		}
+	}
+}
+
+// winding returns the winding number of poly around p, and whether p is on
+// the boundary.
+func winding(poly []I2, p I2) (w int, onEdge bool) {
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		s := SignedArea2(a, b, p)
+		if s == 0 && p.InRect(a.ClampHi(b), a.ClampLo(b)) {
+			onEdge = true
+		}
+		switch {
+		case a.Y <= p.Y && p.Y < b.Y && s > 0:
+			w++
+		case b.Y <= p.Y && p.Y < a.Y && s < 0:
+			w--
+		}
+	}
+	return w, onEdge
+}
+
+func TestRasterPolygon(t *testing.T) {
+	clip := NewRect(-10, -10, 10, 10)
+	polys := [][]I2{
+		{{0, -5}, {3, 4}, {-5, -2}, {5, -2}, {-3, 4}},                                               // star
+		{{-6, -6}, {6, -6}, {6, 6}, {-6, 6}, {-3, -3}, {-3, 3}, {3, 3}, {3, -3}, {-3, -3}, {-6, 6}}, // square with a hole
+		{{-4, 0}, {0, -3}, {4, 0}, {0, 3}},                                                          // diamond
+		{{-20, -1}, {20, 1}, {1, 25}},                                                               // mostly clipped
+		{{2, 2}},                                                                                    // single point
+		{{-3, 1}, {4, 1}},                                                                           // degenerate
+	}
+	if s := 24; bits.UintSize == 64 {
+		// Much larger than clip, which should be cheap.
+		h := 1 << s
+		polys = append(polys,
+			[]I2{{-h, -h}, {h, -h}, {h, h}, {-h, h}},
+			[]I2{{-h, -2}, {h, 3}, {5, h}},
+		)
+	}
+	for i, poly := range polys {
+		for _, rule := range []FillRule{EvenOdd, NonZero} {
+			r := Raster{Clip: clip, Rule: rule}
+			got := collectCells(t, "Polygon", r.Polygon(poly))
+			for p := range clip.Points(RowMajor) {
+				w, on := winding(poly, p)
+				if want := on || rule.inside(w); got[p] != want {
+					t.Errorf("Polygon #%d (rule %d) at %v: got %t, want %t", i, rule, p, got[p], want)
+				}
+			}
+
+			// Every cell with a point inside must be in the conservative
+			// result. Check the centres, corners and edge midpoints.
+			r.Conservative = true
+			cons := collectCells(t, "Polygon", r.Polygon(poly))
+			scaled := make([]I2, len(poly))
+			for j, v := range poly {
+				scaled[j] = v.Mul(2)
+			}
+			for p := range clip.Points(RowMajor) {
+				for q := range NewRect(-1, -1, 2, 2).Points(RowMajor) {
+					w, on := winding(scaled, p.Mul(2).Add(q))
+					if (on || rule.inside(w)) && !cons[p] {
+						t.Errorf("Polygon #%d (rule %d, conservative) at %v: missing, but %v is inside", i, rule, p, q)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRasterClip(t *testing.T) {
+	r := Raster{Clip: NewRect(3, 3, 5, 4)}
+	if got, want := slices.Collect(r.Circle(I2{0, 0}, 100)), []I2{{3, 3}, {4, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Circle clipped: got %v, want %v", got, want)
+	}
+	if got := slices.Collect(Raster{}.Circle(I2{}, 5)); len(got) != 0 {
+		t.Errorf("Circle with zero Raster: got %v, want none", got)
+	}
+	n := 0
+	for range r.Polygon([]I2{{0, 0}, {10, 0}, {10, 10}}) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("Polygon break: got %d cells, want 1", n)
+	}
+}
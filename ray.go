@@ -14,6 +14,8 @@
 
 package vec
 
+import "math"
+
 func divDown(n, d int) (q int) {
 	if n >= 0 {
 		return n / d
@@ -84,3 +86,60 @@ func CellsTouchingSegment(cellSize, start, end I2, touch func(cell I2) bool) boo
 		//log.Printf("CellsTouchingSegment general case: p, t = %v, %v", p, t)
 	}
 }
+
+func cell3(v, cellSize I3) (w I3) {
+	w.X = divDown(v.X, cellSize.X)
+	w.Y = divDown(v.Y, cellSize.Y)
+	w.Z = divDown(v.Z, cellSize.Z)
+	return
+}
+
+// firstCrossing returns how far (as a fraction of v) from start it is to
+// the next cell boundary along one axis, and how far between subsequent
+// boundaries. Both are +Inf if the segment doesn't move along the axis.
+func firstCrossing(p, s, start, v, cellSize int) (t, d float64) {
+	switch {
+	case s > 0:
+		t = float64((p+1)*cellSize - start)
+	case s < 0:
+		t = float64(start - p*cellSize)
+	default:
+		return math.Inf(1), math.Inf(1)
+	}
+	return t / float64(v), float64(cellSize) / float64(v)
+}
+
+// VoxelsTouchingSegment calls touch for every cuboid cell that the 3D line
+// segment (start-end) overlaps, stopping at end or when touch returns false.
+// It is the 3D equivalent of CellsTouchingSegment.
+func VoxelsTouchingSegment(cellSize, start, end I3, touch func(cell I3) bool) bool {
+	p, q := cell3(start, cellSize), cell3(end, cellSize)
+	s := q.Sub(p).Sgn()
+	v := end.Sub(start).EMul(s)
+	var t, d F3
+	t.X, d.X = firstCrossing(p.X, s.X, start.X, v.X, cellSize.X)
+	t.Y, d.Y = firstCrossing(p.Y, s.Y, start.Y, v.Y, cellSize.Y)
+	t.Z, d.Z = firstCrossing(p.Z, s.Z, start.Z, v.Z, cellSize.Z)
+	for {
+		if !touch(p) {
+			return false
+		}
+		if p == q {
+			return true
+		}
+		if t.X > 1 && t.Y > 1 && t.Z > 1 {
+			return true
+		}
+		switch {
+		case t.X < t.Y && t.X < t.Z:
+			t.X += d.X
+			p.X += s.X
+		case t.Y <= t.Z:
+			t.Y += d.Y
+			p.Y += s.Y
+		default:
+			t.Z += d.Z
+			p.Z += s.Z
+		}
+	}
+}
@@ -15,7 +15,6 @@
 package vec
 
 import (
-	"log"
 	"math/rand"
 	"testing"
 )
@@ -56,8 +55,33 @@ func TestCellsTouchingSegmentHalts(t *testing.T) {
 	for i := 0; i < 1000; i++ {
 		start := I2{rand.Intn(1000) - 500, rand.Intn(1000) - 500}
 		end := I2{rand.Intn(1000) - 500, rand.Intn(1000) - 500}
-		test.Logf("test %d: %v-%v", i, start, end)
+		t.Logf("test %d: %v-%v", i, start, end)
 		CellsTouchingSegment(I2{16, 16}, start, end, func(I2) bool { return true })
-		test.Logf("test %d pass", i)
+		t.Logf("test %d pass", i)
+	}
+}
+
+func TestVoxelsTouchingSegment(t *testing.T) {
+	tests := []struct {
+		start, end I3
+		want       int
+	}{
+		{start: I3{0, 0, 0}, end: I3{0, 0, 0}, want: 1},
+		{start: I3{0, 0, 0}, end: I3{0, 0, 16}, want: 2},
+		{start: I3{0, 0, 0}, end: I3{0, 0, -1}, want: 2},
+		{start: I3{0, 0, 0}, end: I3{159, 15, 0}, want: 10},
+		{start: I3{8, 8, 8}, end: I3{40, 8, 40}, want: 5},
+		{start: I3{1, 2, 3}, end: I3{47, 31, 17}, want: 5},
+	}
+
+	for i, test := range tests {
+		got := 0
+		VoxelsTouchingSegment(I3{16, 16, 16}, test.start, test.end, func(I3) bool {
+			got++
+			return true
+		})
+		if got != test.want {
+			t.Errorf("Test %d: got %d want %d", i, got, test.want)
+		}
 	}
 }
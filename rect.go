@@ -14,6 +14,10 @@
 
 package vec
 
+import "iter"
+
+// Rect is the half-open rectangle [UL.X, DR.X) * [UL.Y, DR.Y): it contains
+// the points p with UL.X <= p.X < DR.X and UL.Y <= p.Y < DR.Y.
 type Rect struct {
 	UL, DR I2
 }
@@ -26,16 +30,20 @@ func (r Rect) C() (x0, y0, x1, y1 int) {
 	return r.UL.X, r.UL.Y, r.DR.X, r.DR.Y
 }
 
+// Contains reports whether p is in r (see ContainsWith for closed
+// rectangles).
 func (r Rect) Contains(p I2) bool {
-	return p.X >= r.UL.X && p.X < r.DR.X && p.Y >= r.UL.Y && p.Y < r.DR.Y
+	return r.ContainsWith(p, HalfOpen)
 }
 
 func (r Rect) Expand(e I2) Rect {
 	return Rect{UL: r.UL.Sub(e), DR: r.DR.Add(e)}
 }
 
+// Overlaps reports whether r and s have any points in common. Empty
+// rectangles overlap nothing.
 func (r Rect) Overlaps(s Rect) bool {
-	return s.DR.X > r.UL.X && s.UL.X < r.DR.X && s.DR.Y > r.UL.Y && s.UL.Y < r.DR.Y
+	return !r.Intersect(s).Empty()
 }
 
 func (r Rect) Translate(p I2) Rect {
@@ -53,3 +61,162 @@ func (r Rect) Reposition(ul I2) Rect {
 func (r Rect) Resize(sz I2) Rect {
 	return Rect{UL: r.UL, DR: r.UL.Add(sz)}
 }
+
+// Canon returns r with its corners swapped as needed, so that UL is the
+// componentwise minimum and DR the maximum.
+func (r Rect) Canon() Rect {
+	return Rect{UL: r.UL.ClampHi(r.DR), DR: r.DR.ClampLo(r.UL)}
+}
+
+// Empty reports whether r contains no points.
+func (r Rect) Empty() bool {
+	return r.X().Empty(HalfOpen) || r.Y().Empty(HalfOpen)
+}
+
+// Eq reports whether r and s contain the same points. All empty rectangles
+// are equal.
+func (r Rect) Eq(s Rect) bool {
+	return r == s || (r.Empty() && s.Empty())
+}
+
+// Area returns the number of points in r.
+func (r Rect) Area() int {
+	if r.Empty() {
+		return 0
+	}
+	return r.Size().Area()
+}
+
+// Center returns the middle point of r, rounded towards UL.
+func (r Rect) Center() I2 {
+	return I2{divDown(r.UL.X+r.DR.X, 2), divDown(r.UL.Y+r.DR.Y, 2)}
+}
+
+// In reports whether every point of r is in s. An empty r is in every s.
+func (r Rect) In(s Rect) bool {
+	return r.Empty() || (r.UL.X >= s.UL.X && r.UL.Y >= s.UL.Y && r.DR.X <= s.DR.X && r.DR.Y <= s.DR.Y)
+}
+
+// Intersect returns the largest rectangle contained in both r and s. If
+// they don't overlap, it returns the zero Rect.
+func (r Rect) Intersect(s Rect) Rect {
+	t := Rect{UL: r.UL.ClampLo(s.UL), DR: r.DR.ClampHi(s.DR)}
+	if t.Empty() {
+		return Rect{}
+	}
+	return t
+}
+
+// Union returns the smallest rectangle containing both r and s. Empty
+// rectangles are ignored.
+func (r Rect) Union(s Rect) Rect {
+	switch {
+	case r.Empty():
+		return s
+	case s.Empty():
+		return r
+	}
+	return Rect{UL: r.UL.ClampHi(s.UL), DR: r.DR.ClampLo(s.DR)}
+}
+
+// BoundingRect returns the smallest Rect containing all the points (see
+// Points.BoundingBox).
+func BoundingRect(points ...I2) Rect {
+	return Points(points).BoundingBox()
+}
+
+// NearestPoint locates the point in r that is closest to p, and returns the
+// point and the square of the distance. Like Contains, it considers only
+// the integer points r contains, so the result is at most DR-(1,1); for
+// the nearest point of the area covered by r, use r.FRect().NearestPoint.
+// The distance is exact if all coordinates have absolute value less than
+// 2^30. r must not be empty.
+func (r Rect) NearestPoint(p I2) (I2, int64) {
+	q := I2{max(r.UL.X, min(p.X, r.DR.X-1)), max(r.UL.Y, min(p.Y, r.DR.Y-1))}
+	d := p.Sub(q)
+	return q, d.Dot(d)
+}
+
+// gap returns the signed distance along one axis from [lo0, hi0) to
+// [lo1, hi1), or 0 if they touch or overlap.
+func gap[T Number](lo0, hi0, lo1, hi1 T) T {
+	switch {
+	case lo1 >= hi0:
+		return lo1 - hi0
+	case hi1 <= lo0:
+		return hi1 - lo0
+	default:
+		return 0
+	}
+}
+
+// Separation returns the vector between the areas covered by r and s: how
+// far s lies to the right of or below r (negative components mean left or
+// above), or 0 in each axis where their extents touch or overlap. It is
+// zero exactly when r and s overlap or touch. The square of the distance
+// between them is the dot product of the result with itself.
+func (r Rect) Separation(s Rect) I2 {
+	return I2{gap(r.UL.X, r.DR.X, s.UL.X, s.DR.X), gap(r.UL.Y, r.DR.Y, s.UL.Y, s.DR.Y)}
+}
+
+// pushOut returns the shortest signed distance to move [lo1, hi1) along
+// one axis so that it no longer overlaps [lo0, hi0).
+func pushOut[T Number](lo0, hi0, lo1, hi1 T) T {
+	left, right := lo0-hi1, hi0-lo1
+	if -left <= right {
+		return left
+	}
+	return right
+}
+
+// PushOut returns the smallest translation, along a single axis, that moves
+// s so that it no longer overlaps r (so that they only touch). It returns
+// zero if they don't overlap. When both axes need the same distance, it
+// moves s horizontally.
+func (r Rect) PushOut(s Rect) I2 {
+	if !r.Overlaps(s) {
+		return I2{}
+	}
+	x := pushOut(r.UL.X, r.DR.X, s.UL.X, s.DR.X)
+	y := pushOut(r.UL.Y, r.DR.Y, s.UL.Y, s.DR.Y)
+	if Abs(x) <= Abs(y) {
+		return I2{x, 0}
+	}
+	return I2{0, y}
+}
+
+// Order is the order in which Points visits the points of a Rect.
+type Order int
+
+// Order values.
+const (
+	// RowMajor visits each row in turn (X varies fastest).
+	RowMajor = Order(iota)
+	// ColumnMajor visits each column in turn (Y varies fastest), in the
+	// same order as RectRange.
+	ColumnMajor
+)
+
+// Points returns an iterator over the integer points contained in r, in
+// the given order. Unlike RectRange, it doesn't allocate.
+func (r Rect) Points(o Order) iter.Seq[I2] {
+	return func(yield func(I2) bool) {
+		if o == ColumnMajor {
+			for x := r.UL.X; x < r.DR.X; x++ {
+				for y := r.UL.Y; y < r.DR.Y; y++ {
+					if !yield(I2{x, y}) {
+						return
+					}
+				}
+			}
+			return
+		}
+		for y := r.UL.Y; y < r.DR.Y; y++ {
+			for x := r.UL.X; x < r.DR.X; x++ {
+				if !yield(I2{x, y}) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -14,7 +14,11 @@
 
 package vec
 
-import "testing"
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
 
 func TestOverlap(t *testing.T) {
 	tests := []struct {
@@ -61,3 +65,191 @@ func TestOverlap(t *testing.T) {
 		}
 	}
 }
+
+func TestRectPoints(t *testing.T) {
+	r := NewRect(1, 2, 3, 4)
+	tests := []struct {
+		o    Order
+		want []I2
+	}{
+		{RowMajor, []I2{{1, 2}, {2, 2}, {1, 3}, {2, 3}}},
+		{ColumnMajor, []I2{{1, 2}, {1, 3}, {2, 2}, {2, 3}}},
+	}
+	for i, test := range tests {
+		if got := slices.Collect(r.Points(test.o)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Points test #%d: got %v, want %v", i, got, test.want)
+		}
+	}
+	if got, want := slices.Collect(r.Points(ColumnMajor)), RectRange(r.UL, r.DR); !reflect.DeepEqual(got, want) {
+		t.Errorf("Points(ColumnMajor): got %v, want RectRange = %v", got, want)
+	}
+	if got := slices.Collect(NewRect(3, 3, 1, 5).Points(RowMajor)); len(got) != 0 {
+		t.Errorf("Points of empty rect: got %v, want none", got)
+	}
+}
+
+func TestRectPointsNoAlloc(t *testing.T) {
+	r := NewRect(0, 0, 1000, 1000)
+	var sum int
+	allocs := testing.AllocsPerRun(3, func() {
+		for p := range r.Points(RowMajor) {
+			sum += p.X
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Points: got %v allocs, want 0", allocs)
+	}
+}
+
+func TestRectAlgebra(t *testing.T) {
+	a, b := NewRect(0, 0, 4, 3), NewRect(2, 1, 6, 5)
+	far := NewRect(10, 10, 12, 12)
+	empty := NewRect(3, 3, 3, 7)
+	tests := []struct {
+		r, s             Rect
+		intersect, union Rect
+		overlaps, in     bool
+	}{
+		{a, b, NewRect(2, 1, 4, 3), NewRect(0, 0, 6, 5), true, false},
+		{a, far, Rect{}, NewRect(0, 0, 12, 12), false, false},
+		{a, NewRect(4, 0, 6, 3), Rect{}, NewRect(0, 0, 6, 3), false, false},
+		{NewRect(1, 1, 2, 2), a, NewRect(1, 1, 2, 2), a, true, true},
+		{empty, a, Rect{}, a, false, true},
+		{a, empty, Rect{}, a, false, false},
+		{empty, empty, Rect{}, empty, false, true},
+	}
+	for i, test := range tests {
+		if got := test.r.Intersect(test.s); got != test.intersect {
+			t.Errorf("Intersect test #%d: got %v, want %v", i, got, test.intersect)
+		}
+		if got := test.r.Union(test.s); got != test.union {
+			t.Errorf("Union test #%d: got %v, want %v", i, got, test.union)
+		}
+		if got := test.r.Overlaps(test.s); got != test.overlaps {
+			t.Errorf("Overlaps test #%d: got %t, want %t", i, got, test.overlaps)
+		}
+		if got := test.r.In(test.s); got != test.in {
+			t.Errorf("In test #%d: got %t, want %t", i, got, test.in)
+		}
+		// Every point of the intersection is in both; every point of
+		// either is in the union.
+		for p := range NewRect(-1, -1, 13, 13).Points(RowMajor) {
+			if got, want := test.r.Intersect(test.s).Contains(p), test.r.Contains(p) && test.s.Contains(p); got != want {
+				t.Errorf("Intersect test #%d: Contains(%v) = %t, want %t", i, p, got, want)
+			}
+			if (test.r.Contains(p) || test.s.Contains(p)) && !test.r.Union(test.s).Contains(p) {
+				t.Errorf("Union test #%d: doesn't contain %v", i, p)
+			}
+		}
+	}
+}
+
+func TestRectProperties(t *testing.T) {
+	tests := []struct {
+		r      Rect
+		canon  Rect
+		empty  bool
+		area   int
+		center I2
+	}{
+		{NewRect(0, 0, 4, 3), NewRect(0, 0, 4, 3), false, 12, I2{2, 1}},
+		{NewRect(4, 3, 0, 0), NewRect(0, 0, 4, 3), true, 0, I2{2, 1}},
+		{NewRect(-3, 5, 0, 2), NewRect(-3, 2, 0, 5), true, 0, I2{-2, 3}},
+		{NewRect(1, 1, 1, 5), NewRect(1, 1, 1, 5), true, 0, I2{1, 3}},
+		{NewRect(-5, -5, -4, -4), NewRect(-5, -5, -4, -4), false, 1, I2{-5, -5}},
+	}
+	for i, test := range tests {
+		if got := test.r.Canon(); got != test.canon {
+			t.Errorf("Canon test #%d: got %v, want %v", i, got, test.canon)
+		}
+		if got := test.r.Empty(); got != test.empty {
+			t.Errorf("Empty test #%d: got %t, want %t", i, got, test.empty)
+		}
+		if got := test.r.Area(); got != test.area {
+			t.Errorf("Area test #%d: got %d, want %d", i, got, test.area)
+		}
+		if got := test.r.Center(); got != test.center {
+			t.Errorf("Center test #%d: got %v, want %v", i, got, test.center)
+		}
+	}
+	if !NewRect(1, 1, 1, 5).Eq(NewRect(7, 2, 3, 2)) {
+		t.Errorf("Eq: empty rects should be equal")
+	}
+	if NewRect(0, 0, 1, 1).Eq(NewRect(0, 0, 1, 2)) {
+		t.Errorf("Eq: different rects should not be equal")
+	}
+	if got, want := BoundingRect(I2{3, -1}, I2{0, 2}, I2{1, 1}), NewRect(0, -1, 4, 3); got != want {
+		t.Errorf("BoundingRect: got %v, want %v", got, want)
+	}
+	if got := BoundingRect(); got != (Rect{}) {
+		t.Errorf("BoundingRect(): got %v, want zero Rect", got)
+	}
+}
+
+func TestRectNearestPoint(t *testing.T) {
+	r := NewRect(0, 0, 4, 3)
+	tests := []struct {
+		p     I2
+		want  I2
+		dist2 int64
+	}{
+		{I2{1, 1}, I2{1, 1}, 0},
+		{I2{-2, 1}, I2{0, 1}, 4},
+		{I2{4, 1}, I2{3, 1}, 1},
+		{I2{6, 5}, I2{3, 2}, 18},
+		{I2{-1, -1}, I2{0, 0}, 2},
+		{I2{2, 3}, I2{2, 2}, 1},
+	}
+	for i, test := range tests {
+		got, d := r.NearestPoint(test.p)
+		if got != test.want || d != test.dist2 {
+			t.Errorf("NearestPoint test #%d: got (%v, %d), want (%v, %d)", i, got, d, test.want, test.dist2)
+		}
+		// It agrees with brute force.
+		best := int64(1 << 62)
+		for q := range r.Points(RowMajor) {
+			e := test.p.Sub(q)
+			best = min(best, e.Dot(e))
+		}
+		if d != best {
+			t.Errorf("NearestPoint test #%d: got distance %d, brute force %d", i, d, best)
+		}
+	}
+}
+
+func TestRectSeparation(t *testing.T) {
+	r := NewRect(0, 0, 10, 10)
+	tests := []struct {
+		s         Rect
+		sep, push I2
+	}{
+		{NewRect(12, 0, 15, 5), I2{2, 0}, I2{}},
+		{NewRect(-5, -8, -3, -2), I2{-3, -2}, I2{}},
+		{NewRect(10, 10, 12, 12), I2{}, I2{}},
+		{NewRect(8, 2, 12, 5), I2{}, I2{2, 0}},
+		{NewRect(2, -3, 5, 1), I2{}, I2{0, -1}},
+		{NewRect(-2, 8, 1, 13), I2{}, I2{-1, 0}},
+		{NewRect(-2, 8, 3, 13), I2{}, I2{0, 2}},
+		{NewRect(1, 1, 3, 3), I2{}, I2{-3, 0}},
+		{NewRect(7, 7, 12, 12), I2{}, I2{3, 0}},
+	}
+	for i, test := range tests {
+		if got := r.Separation(test.s); got != test.sep {
+			t.Errorf("Separation test #%d: got %v, want %v", i, got, test.sep)
+		}
+		got := r.PushOut(test.s)
+		if got != test.push {
+			t.Errorf("PushOut test #%d: got %v, want %v", i, got, test.push)
+		}
+		if moved := test.s.Translate(got); r.Overlaps(moved) {
+			t.Errorf("PushOut test #%d: %v still overlaps %v", i, moved, r)
+		}
+		fr, fs := r.FRect(), test.s.FRect()
+		if got, want := fr.Separation(fs), test.sep.F2(); got != want {
+			t.Errorf("FRect.Separation test #%d: got %v, want %v", i, got, want)
+		}
+		if got, want := fr.PushOut(fs), test.push.F2(); got != want {
+			t.Errorf("FRect.PushOut test #%d: got %v, want %v", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"iter"
+	"slices"
+	"sort"
+)
+
+// Subtract returns non-overlapping rectangles covering the points of r that
+// are not in s: at most a band above s, one either side of s, and a band
+// below s.
+func (r Rect) Subtract(s Rect) []Rect {
+	if r.Empty() {
+		return nil
+	}
+	t := r.Intersect(s)
+	if t.Empty() {
+		return []Rect{r}
+	}
+	var out []Rect
+	if r.UL.Y < t.UL.Y {
+		out = append(out, NewRect(r.UL.X, r.UL.Y, r.DR.X, t.UL.Y))
+	}
+	if r.UL.X < t.UL.X {
+		out = append(out, NewRect(r.UL.X, t.UL.Y, t.UL.X, t.DR.Y))
+	}
+	if t.DR.X < r.DR.X {
+		out = append(out, NewRect(t.DR.X, t.UL.Y, r.DR.X, t.DR.Y))
+	}
+	if t.DR.Y < r.DR.Y {
+		out = append(out, NewRect(r.UL.X, t.DR.Y, r.DR.X, r.DR.Y))
+	}
+	return out
+}
+
+// xspan is the half-open range [lo, hi) of X values.
+type xspan struct{ lo, hi int }
+
+// band is a horizontal strip [y0, y1) of a Region, containing the X ranges
+// in xs, which are sorted, non-empty and neither overlap nor touch.
+type band struct {
+	y0, y1 int
+	xs     []xspan
+}
+
+// Region is a set of points made of non-overlapping rectangles, stored in
+// the same way as X11 and pixman regions: as horizontal bands of
+// rectangles with the same height. Bands are sorted by Y, and touching
+// bands always differ, so every region has exactly one representation.
+// The zero Region is empty. Regions are immutable; the operations return
+// new regions.
+type Region struct {
+	bands []band
+}
+
+// NewRegion returns the region containing the points in any of rs.
+func NewRegion(rs ...Rect) Region {
+	var g Region
+	for _, r := range rs {
+		if r.Empty() {
+			continue
+		}
+		g = g.Union(Region{bands: []band{{r.UL.Y, r.DR.Y, []xspan{{r.UL.X, r.DR.X}}}}})
+	}
+	return g
+}
+
+// Rects returns an iterator over the rectangles making up g, sorted by Y
+// and then X.
+func (g Region) Rects() iter.Seq[Rect] {
+	return func(yield func(Rect) bool) {
+		for _, b := range g.bands {
+			for _, x := range b.xs {
+				if !yield(NewRect(x.lo, b.y0, x.hi, b.y1)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Empty reports whether g contains no points.
+func (g Region) Empty() bool { return len(g.bands) == 0 }
+
+// Eq reports whether g and h contain the same points.
+func (g Region) Eq(h Region) bool {
+	return slices.EqualFunc(g.bands, h.bands, func(a, b band) bool {
+		return a.y0 == b.y0 && a.y1 == b.y1 && slices.Equal(a.xs, b.xs)
+	})
+}
+
+// Area returns the number of points in g.
+func (g Region) Area() (n int) {
+	for r := range g.Rects() {
+		n += r.Area()
+	}
+	return n
+}
+
+// Bounds returns the smallest Rect containing g, or the zero Rect if g is
+// empty.
+func (g Region) Bounds() (r Rect) {
+	for s := range g.Rects() {
+		r = r.Union(s)
+	}
+	return r
+}
+
+// Contains reports whether p is in g.
+func (g Region) Contains(p I2) bool {
+	i := sort.Search(len(g.bands), func(i int) bool { return g.bands[i].y1 > p.Y })
+	if i == len(g.bands) || g.bands[i].y0 > p.Y {
+		return false
+	}
+	xs := g.bands[i].xs
+	j := sort.Search(len(xs), func(j int) bool { return xs[j].hi > p.X })
+	return j < len(xs) && xs[j].lo <= p.X
+}
+
+// Union returns the region of points in g or h.
+func (g Region) Union(h Region) Region {
+	return g.combine(h, func(a, b bool) bool { return a || b })
+}
+
+// Intersect returns the region of points in both g and h.
+func (g Region) Intersect(h Region) Region {
+	return g.combine(h, func(a, b bool) bool { return a && b })
+}
+
+// Subtract returns the region of points in g but not in h.
+func (g Region) Subtract(h Region) Region {
+	return g.combine(h, func(a, b bool) bool { return a && !b })
+}
+
+// Xor returns the region of points in exactly one of g and h.
+func (g Region) Xor(h Region) Region {
+	return g.combine(h, func(a, b bool) bool { return a != b })
+}
+
+// combine returns the region of points for which op returns true, given
+// whether the point is in g and in h.
+func (g Region) combine(h Region, op func(a, b bool) bool) Region {
+	// Split the plane into strips at every band edge of either region.
+	var ys []int
+	for _, b := range g.bands {
+		ys = append(ys, b.y0, b.y1)
+	}
+	for _, b := range h.bands {
+		ys = append(ys, b.y0, b.y1)
+	}
+	slices.Sort(ys)
+	ys = slices.Compact(ys)
+
+	var out Region
+	var i, j int
+	for k := 0; k+1 < len(ys); k++ {
+		y0, y1 := ys[k], ys[k+1]
+		for i < len(g.bands) && g.bands[i].y1 <= y0 {
+			i++
+		}
+		for j < len(h.bands) && h.bands[j].y1 <= y0 {
+			j++
+		}
+		var a, b []xspan
+		if i < len(g.bands) && g.bands[i].y0 <= y0 {
+			a = g.bands[i].xs
+		}
+		if j < len(h.bands) && h.bands[j].y0 <= y0 {
+			b = h.bands[j].xs
+		}
+		xs := combineSpans(a, b, op)
+		if len(xs) == 0 {
+			continue
+		}
+		// Coalesce with the band above if it is the same.
+		if n := len(out.bands); n > 0 && out.bands[n-1].y1 == y0 && slices.Equal(out.bands[n-1].xs, xs) {
+			out.bands[n-1].y1 = y1
+			continue
+		}
+		out.bands = append(out.bands, band{y0, y1, xs})
+	}
+	return out
+}
+
+// combineSpans returns the X ranges for which op returns true, given
+// whether X is in a and in b.
+func combineSpans(a, b []xspan, op func(a, b bool) bool) []xspan {
+	var xs []int
+	for _, s := range a {
+		xs = append(xs, s.lo, s.hi)
+	}
+	for _, s := range b {
+		xs = append(xs, s.lo, s.hi)
+	}
+	slices.Sort(xs)
+	xs = slices.Compact(xs)
+
+	var out []xspan
+	var i, j int
+	for k := 0; k+1 < len(xs); k++ {
+		x0, x1 := xs[k], xs[k+1]
+		for i < len(a) && a[i].hi <= x0 {
+			i++
+		}
+		for j < len(b) && b[j].hi <= x0 {
+			j++
+		}
+		inA := i < len(a) && a[i].lo <= x0
+		inB := j < len(b) && b[j].lo <= x0
+		if !op(inA, inB) {
+			continue
+		}
+		if n := len(out); n > 0 && out[n-1].hi == x0 {
+			out[n-1].hi = x1
+			continue
+		}
+		out = append(out, xspan{x0, x1})
+	}
+	return out
+}
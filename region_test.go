@@ -0,0 +1,134 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestRectSubtract(t *testing.T) {
+	r := NewRect(0, 0, 10, 10)
+	tests := []struct {
+		r, s Rect
+		want []Rect
+	}{
+		{r, NewRect(3, 4, 6, 7), []Rect{NewRect(0, 0, 10, 4), NewRect(0, 4, 3, 7), NewRect(6, 4, 10, 7), NewRect(0, 7, 10, 10)}},
+		{r, NewRect(-5, -5, 5, 5), []Rect{NewRect(5, 0, 10, 5), NewRect(0, 5, 10, 10)}},
+		{r, NewRect(10, 0, 20, 10), []Rect{r}},
+		{r, NewRect(-1, -1, 11, 11), nil},
+		{r, NewRect(0, 2, 10, 3), []Rect{NewRect(0, 0, 10, 2), NewRect(0, 3, 10, 10)}},
+		{NewRect(5, 5, 5, 9), r, nil},
+	}
+	for i, test := range tests {
+		if got := test.r.Subtract(test.s); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Subtract test #%d: got %v, want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestRegionOps(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randRect := func() Rect {
+		x, y := rng.Intn(12), rng.Intn(12)
+		return NewRect(x, y, x+rng.Intn(6), y+rng.Intn(6))
+	}
+	randRegion := func() (Region, []Rect) {
+		rs := make([]Rect, rng.Intn(4))
+		for i := range rs {
+			rs[i] = randRect()
+		}
+		return NewRegion(rs...), rs
+	}
+	in := func(rs []Rect, p I2) bool {
+		return slices.ContainsFunc(rs, func(r Rect) bool { return r.Contains(p) })
+	}
+	ops := []struct {
+		name string
+		f    func(g, h Region) Region
+		want func(a, b bool) bool
+	}{
+		{"Union", Region.Union, func(a, b bool) bool { return a || b }},
+		{"Intersect", Region.Intersect, func(a, b bool) bool { return a && b }},
+		{"Subtract", Region.Subtract, func(a, b bool) bool { return a && !b }},
+		{"Xor", Region.Xor, func(a, b bool) bool { return a != b }},
+	}
+	for n := 0; n < 200; n++ {
+		g, grs := randRegion()
+		h, hrs := randRegion()
+		for _, op := range ops {
+			got := op.f(g, h)
+			area, bounds := 0, Rect{}
+			for p := range NewRect(-1, -1, 19, 19).Points(RowMajor) {
+				want := op.want(in(grs, p), in(hrs, p))
+				if got.Contains(p) != want {
+					t.Fatalf("%v.%s(%v) contains %v: got %t, want %t", grs, op.name, hrs, p, !want, want)
+				}
+				if want {
+					area++
+					bounds = bounds.Union(NewRect(p.X, p.Y, p.X+1, p.Y+1))
+				}
+			}
+			if got.Area() != area {
+				t.Errorf("%v.%s(%v).Area() = %d, want %d", grs, op.name, hrs, got.Area(), area)
+			}
+			if got.Bounds() != bounds {
+				t.Errorf("%v.%s(%v).Bounds() = %v, want %v", grs, op.name, hrs, got.Bounds(), bounds)
+			}
+			checkRegion(t, got)
+		}
+		// The representation is canonical.
+		if !g.Union(h).Eq(h.Union(g)) || !g.Xor(h).Eq(g.Subtract(h).Union(h.Subtract(g))) {
+			t.Errorf("%v, %v: representations differ", grs, hrs)
+		}
+	}
+}
+
+// checkRegion checks that the rects of g are sorted, disjoint, and don't
+// touch horizontally.
+func checkRegion(t *testing.T, g Region) {
+	t.Helper()
+	rs := slices.Collect(g.Rects())
+	for i, r := range rs {
+		if r.Empty() {
+			t.Errorf("region has empty rect %v", r)
+		}
+		for _, s := range rs[i+1:] {
+			if r.Overlaps(s) {
+				t.Errorf("region rects %v and %v overlap", r, s)
+			}
+			if r.UL.Y == s.UL.Y && r.DR.X >= s.UL.X {
+				t.Errorf("region rects %v and %v out of order or touching", r, s)
+			}
+		}
+	}
+}
+
+func TestRegionCoalesce(t *testing.T) {
+	g := NewRegion(NewRect(0, 0, 4, 2), NewRect(0, 2, 4, 5), NewRect(4, 0, 6, 5))
+	if got, want := slices.Collect(g.Rects()), []Rect{NewRect(0, 0, 6, 5)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Rects: got %v, want %v", got, want)
+	}
+	g = g.Subtract(NewRegion(NewRect(2, 2, 3, 3)))
+	want := []Rect{NewRect(0, 0, 6, 2), NewRect(0, 2, 2, 3), NewRect(3, 2, 6, 3), NewRect(0, 3, 6, 5)}
+	if got := slices.Collect(g.Rects()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Rects after Subtract: got %v, want %v", got, want)
+	}
+	if !(Region{}).Empty() || !NewRegion(NewRect(1, 1, 1, 5)).Empty() {
+		t.Errorf("Empty: want empty regions")
+	}
+}
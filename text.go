@@ -0,0 +1,274 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+// This file implements compact text forms for the basic types:
+//
+//	I2, F2      "3,4"  (parentheses are optional when parsing: "(3,4)")
+//	Rect, Edge  "(0,0)-(10,10)"
+//	Direction   "Left"
+//
+// Each type implements fmt.Stringer, encoding.TextMarshaler and
+// encoding.TextUnmarshaler (so they can be used as JSON values and map keys),
+// and pointers to each implement flag.Value.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// splitPair splits "x,y" or "(x,y)" into "x" and "y".
+func splitPair(s string) (string, string, bool) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		s = s[1 : len(s)-1]
+	}
+	x, y, ok := strings.Cut(s, ",")
+	return strings.TrimSpace(x), strings.TrimSpace(y), ok
+}
+
+// splitSegment splits "(a)-(b)" into "(a)" and "(b)".
+func splitSegment(s string) (string, string, bool) {
+	s = strings.TrimSpace(s)
+	i := strings.Index(s, ")")
+	if !strings.HasPrefix(s, "(") || i < 0 {
+		return "", "", false
+	}
+	rest := strings.TrimSpace(s[i+1:])
+	if !strings.HasPrefix(rest, "-") {
+		return "", "", false
+	}
+	rest = strings.TrimSpace(rest[1:])
+	if !strings.HasPrefix(rest, "(") || !strings.HasSuffix(rest, ")") {
+		return "", "", false
+	}
+	return s[:i+1], rest, true
+}
+
+// ParseI2 parses an I2 of the form "x,y" or "(x,y)".
+func ParseI2(s string) (I2, error) {
+	xs, ys, ok := splitPair(s)
+	if !ok {
+		return I2{}, fmt.Errorf("parsing I2 %q: missing comma", s)
+	}
+	x, err := strconv.Atoi(xs)
+	if err != nil {
+		return I2{}, fmt.Errorf("parsing I2 %q: %w", s, err)
+	}
+	y, err := strconv.Atoi(ys)
+	if err != nil {
+		return I2{}, fmt.Errorf("parsing I2 %q: %w", s, err)
+	}
+	return I2{x, y}, nil
+}
+
+// String returns v in the form "x,y".
+func (v I2) String() string { return strconv.Itoa(v.X) + "," + strconv.Itoa(v.Y) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (v I2) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *I2) UnmarshalText(b []byte) error { return v.Set(string(b)) }
+
+// Set implements flag.Value.
+func (v *I2) Set(s string) error {
+	w, err := ParseI2(s)
+	if err != nil {
+		return err
+	}
+	*v = w
+	return nil
+}
+
+// ParseF2 parses an F2 of the form "x,y" or "(x,y)".
+func ParseF2(s string) (F2, error) {
+	xs, ys, ok := splitPair(s)
+	if !ok {
+		return F2{}, fmt.Errorf("parsing F2 %q: missing comma", s)
+	}
+	x, err := strconv.ParseFloat(xs, 64)
+	if err != nil {
+		return F2{}, fmt.Errorf("parsing F2 %q: %w", s, err)
+	}
+	y, err := strconv.ParseFloat(ys, 64)
+	if err != nil {
+		return F2{}, fmt.Errorf("parsing F2 %q: %w", s, err)
+	}
+	return F2{x, y}, nil
+}
+
+// String returns v in the form "x,y", using the shortest representation
+// of each component that parses back to the same value.
+func (v F2) String() string {
+	return strconv.FormatFloat(v.X, 'g', -1, 64) + "," + strconv.FormatFloat(v.Y, 'g', -1, 64)
+}
+
+// Format implements fmt.Formatter. The verb, flags, width and precision
+// are applied to each component, e.g. fmt.Sprintf("%.2f", F2{1, 2}) is
+// "1.00,2.00". The verbs v and s behave like g.
+func (v F2) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		if _, ok := f.Precision(); !ok {
+			fmt.Fprint(f, v.String())
+			return
+		}
+		verb = 'g'
+	}
+	fs := fmt.FormatString(f, verb)
+	fmt.Fprintf(f, fs+","+fs, v.X, v.Y)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v F2) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *F2) UnmarshalText(b []byte) error { return v.Set(string(b)) }
+
+// Set implements flag.Value.
+func (v *F2) Set(s string) error {
+	w, err := ParseF2(s)
+	if err != nil {
+		return err
+	}
+	*v = w
+	return nil
+}
+
+// parseCorners parses "(x0,y0)-(x1,y1)" into two I2s.
+func parseCorners(s string) (I2, I2, error) {
+	us, vs, ok := splitSegment(s)
+	if !ok {
+		return I2{}, I2{}, fmt.Errorf("%q is not of the form (x0,y0)-(x1,y1)", s)
+	}
+	u, err := ParseI2(us)
+	if err != nil {
+		return I2{}, I2{}, err
+	}
+	v, err := ParseI2(vs)
+	if err != nil {
+		return I2{}, I2{}, err
+	}
+	return u, v, nil
+}
+
+// ParseRect parses a Rect of the form "(x0,y0)-(x1,y1)".
+func ParseRect(s string) (Rect, error) {
+	ul, dr, err := parseCorners(s)
+	if err != nil {
+		return Rect{}, fmt.Errorf("parsing Rect: %w", err)
+	}
+	return Rect{UL: ul, DR: dr}, nil
+}
+
+// String returns r in the form "(x0,y0)-(x1,y1)".
+func (r Rect) String() string { return "(" + r.UL.String() + ")-(" + r.DR.String() + ")" }
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Rect) MarshalText() ([]byte, error) { return []byte(r.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *Rect) UnmarshalText(b []byte) error { return r.Set(string(b)) }
+
+// Set implements flag.Value.
+func (r *Rect) Set(s string) error {
+	q, err := ParseRect(s)
+	if err != nil {
+		return err
+	}
+	*r = q
+	return nil
+}
+
+// ParseEdge parses an Edge of the form "(x0,y0)-(x1,y1)".
+func ParseEdge(s string) (Edge, error) {
+	u, v, err := parseCorners(s)
+	if err != nil {
+		return Edge{}, fmt.Errorf("parsing Edge: %w", err)
+	}
+	return Edge{u, v}, nil
+}
+
+// String returns e in the form "(x0,y0)-(x1,y1)".
+func (e Edge) String() string { return "(" + e.U.String() + ")-(" + e.V.String() + ")" }
+
+// MarshalText implements encoding.TextMarshaler.
+func (e Edge) MarshalText() ([]byte, error) { return []byte(e.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (e *Edge) UnmarshalText(b []byte) error { return e.Set(string(b)) }
+
+// Set implements flag.Value.
+func (e *Edge) Set(s string) error {
+	f, err := ParseEdge(s)
+	if err != nil {
+		return err
+	}
+	*e = f
+	return nil
+}
+
+var directionNames = [...]string{
+	Left:      "Left",
+	Right:     "Right",
+	Up:        "Up",
+	Down:      "Down",
+	UpLeft:    "UpLeft",
+	UpRight:   "UpRight",
+	DownLeft:  "DownLeft",
+	DownRight: "DownRight",
+}
+
+// ParseDirection parses a Direction name, such as "Left". Case is ignored.
+func ParseDirection(s string) (Direction, error) {
+	t := strings.TrimSpace(s)
+	for d, n := range directionNames {
+		if strings.EqualFold(t, n) {
+			return Direction(d), nil
+		}
+	}
+	return 0, fmt.Errorf("parsing Direction %q: unknown direction", s)
+}
+
+// String returns the name of d, such as "Left".
+func (d Direction) String() string {
+	if d < 0 || int(d) >= len(directionNames) {
+		return "Direction(" + strconv.Itoa(int(d)) + ")"
+	}
+	return directionNames[d]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Direction) MarshalText() ([]byte, error) {
+	if d < 0 || int(d) >= len(directionNames) {
+		return nil, fmt.Errorf("invalid Direction %d", int(d))
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Direction) UnmarshalText(b []byte) error { return d.Set(string(b)) }
+
+// Set implements flag.Value.
+func (d *Direction) Set(s string) error {
+	e, err := ParseDirection(s)
+	if err != nil {
+		return err
+	}
+	*d = e
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestTextRoundTrip(t *testing.T) {
+	tests := []struct {
+		v    fmt.Stringer
+		want string
+	}{
+		{I2{3, -4}, "3,-4"},
+		{F2{0.5, -1e-20}, "0.5,-1e-20"},
+		{NewRect(-1, -2, 10, 10), "(-1,-2)-(10,10)"},
+		{Edge{I2{0, 0}, I2{-3, 7}}, "(0,0)-(-3,7)"},
+		{Down, "Down"},
+	}
+	for i, test := range tests {
+		if got := test.v.String(); got != test.want {
+			t.Errorf("test #%d: String() = %q, want %q", i, got, test.want)
+		}
+		p := reflect.New(reflect.TypeOf(test.v))
+		if err := p.Interface().(flag.Value).Set(test.want); err != nil {
+			t.Errorf("test #%d: Set(%q) = %v", i, test.want, err)
+			continue
+		}
+		if got := p.Elem().Interface(); got != test.v {
+			t.Errorf("test #%d: Set(%q) gave %v, want %v", i, test.want, got, test.v)
+		}
+	}
+}
+
+func TestTextParse(t *testing.T) {
+	if got, err := ParseI2(" ( 3 , 4 ) "); err != nil || got != (I2{3, 4}) {
+		t.Errorf("ParseI2 = %v, %v; want 3,4, nil", got, err)
+	}
+	if got, err := ParseRect("(0,0) - (10, 10)"); err != nil || got != NewRect(0, 0, 10, 10) {
+		t.Errorf("ParseRect = %v, %v; want (0,0)-(10,10), nil", got, err)
+	}
+	if got, err := ParseDirection("left"); err != nil || got != Left {
+		t.Errorf("ParseDirection = %v, %v; want Left, nil", got, err)
+	}
+	for _, s := range []string{"", "3", "3,x", "(3,4", "(0,0)(1,1)", "(0,0)-1,1"} {
+		if _, err := ParseRect(s); err == nil {
+			t.Errorf("ParseRect(%q) = _, nil, want error", s)
+		}
+	}
+	if _, err := ParseI2("1.5,2"); err == nil {
+		t.Errorf("ParseI2(1.5,2) = _, nil, want error")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	in := struct {
+		M map[I2]Direction
+		R Rect
+	}{
+		M: map[I2]Direction{{1, 2}: Left, {-3, 4}: Up},
+		R: NewRect(0, 0, 10, 10),
+	}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	if got, want := string(b), `{"M":{"-3,4":"Up","1,2":"Left"},"R":"(0,0)-(10,10)"}`; got != want {
+		t.Errorf("json.Marshal = %s, want %s", got, want)
+	}
+	out := in
+	out.M, out.R = nil, Rect{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("json.Unmarshal = %v, want %v", out, in)
+	}
+}
+
+func TestF2Format(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "1.5,-0.25"},
+		{"%.1f", "1.5,-0.2"},
+		{"%6.2f", "  1.50, -0.25"},
+		{"%.2v", "1.5,-0.25"},
+		{"%e", "1.500000e+00,-2.500000e-01"},
+	}
+	for _, test := range tests {
+		if got := fmt.Sprintf(test.format, F2{1.5, -0.25}); got != test.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", test.format, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import "math"
+
+// Integer is a constraint matching any integer type. It is equivalent to
+// golang.org/x/exp/constraints.Integer.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is a constraint matching any floating-point type. It is equivalent
+// to golang.org/x/exp/constraints.Float.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint matching any integer or floating-point type.
+type Number interface {
+	Integer | Float
+}
+
+// V2 is a pair of numbers of any type, (X,Y). It has the methods of both
+// I2 and F2, so that vectors of int32, int64, float32, etc. have the same API.
+type V2[T Number] struct{ X, Y T }
+
+// NewV2 is a convenience function for creating a V2.
+func NewV2[T Number](x, y T) V2[T] { return V2[T]{x, y} }
+
+// V2FromI2 converts an I2 to a V2.
+func V2FromI2[T Number](v I2) V2[T] { return V2[T]{T(v.X), T(v.Y)} }
+
+// V2FromF2 converts an F2 to a V2. If T is an integer type, the components
+// are rounded to the nearest integer.
+func V2FromF2[T Number](v F2) V2[T] { return V2[T]{fromFloat[T](v.X), fromFloat[T](v.Y)} }
+
+// isFloat reports whether T is a floating-point type.
+func isFloat[T Number]() bool {
+	h := 0.5
+	return T(h) != 0
+}
+
+// fromFloat converts f to T, rounding to nearest if T is an integer type.
+func fromFloat[T Number](f float64) T {
+	if isFloat[T]() {
+		return T(f)
+	}
+	return T(math.Round(f))
+}
+
+// C returns the components of v (X,Y).
+func (v V2[T]) C() (T, T) { return v.X, v.Y }
+
+// I2 converts this V2 to an I2. Float components are rounded to the nearest
+// integer; integer components are converted directly.
+func (v V2[T]) I2() I2 {
+	if isFloat[T]() {
+		return I2{fromFloat[int](float64(v.X)), fromFloat[int](float64(v.Y))}
+	}
+	return I2{int(v.X), int(v.Y)}
+}
+
+// F2 converts this V2 to an F2, which may potentially cause loss of precision.
+func (v V2[T]) F2() F2 { return F2{float64(v.X), float64(v.Y)} }
+
+// Add returns v + w.
+func (v V2[T]) Add(w V2[T]) V2[T] { return V2[T]{v.X + w.X, v.Y + w.Y} }
+
+// Sub returns v - w.
+func (v V2[T]) Sub(w V2[T]) V2[T] { return V2[T]{v.X - w.X, v.Y - w.Y} }
+
+// Mul returns the scalar product k * v.
+func (v V2[T]) Mul(k T) V2[T] { return V2[T]{v.X * k, v.Y * k} }
+
+// Div divides both components by k.
+func (v V2[T]) Div(k T) V2[T] { return V2[T]{v.X / k, v.Y / k} }
+
+// EMul returns the element-wise product of v and w.
+func (v V2[T]) EMul(w V2[T]) V2[T] { return V2[T]{v.X * w.X, v.Y * w.Y} }
+
+// EDiv returns the element-wise quotient of v and w.
+func (v V2[T]) EDiv(w V2[T]) V2[T] { return V2[T]{v.X / w.X, v.Y / w.Y} }
+
+// Sgn returns a "unit-ish" vector (each component is normalised to -1, 0 or 1).
+func (v V2[T]) Sgn() V2[T] { return V2[T]{sgn(v.X), sgn(v.Y)} }
+
+// sgn is the sign of x (-1, 0, or 1) for any number type.
+func sgn[T Number](x T) T {
+	switch {
+	case x < 0:
+		one := T(1)
+		return -one
+	case x > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Area returns the product of X and Y.
+func (v V2[T]) Area() T { return v.X * v.Y }
+
+// ClampLo returns v, but with components clamped below by components of e.
+func (v V2[T]) ClampLo(e V2[T]) V2[T] {
+	if v.X < e.X {
+		v.X = e.X
+	}
+	if v.Y < e.Y {
+		v.Y = e.Y
+	}
+	return v
+}
+
+// ClampHi returns v, but with components clamped above by components of e.
+func (v V2[T]) ClampHi(e V2[T]) V2[T] {
+	if v.X >= e.X {
+		v.X = e.X
+	}
+	if v.Y >= e.Y {
+		v.Y = e.Y
+	}
+	return v
+}
+
+// Dot returns the dot product, v dot w. For small integer types this may
+// overflow; convert to a wider type first if that is a concern.
+func (v V2[T]) Dot(w V2[T]) T { return v.X*w.X + v.Y*w.Y }
+
+// Norm returns the length of v (the square root of v dot v).
+func (v V2[T]) Norm() float64 { return v.F2().Norm() }
+
+// Unit returns the unit vector pointing in the same direction as v. The
+// result is an F2, since unit vectors are rarely representable in integers.
+func (v V2[T]) Unit() F2 { return v.F2().Unit() }
+
+// Normal returns a vector perpendicular to v of the same length.
+func (v V2[T]) Normal() V2[T] { return V2[T]{-v.Y, v.X} }
+
+// Arg returns the angle between the X-axis and the vector.
+func (v V2[T]) Arg() float64 { return v.F2().Arg() }
+
+// Cmul returns the complex product v * w, where the X components are treated as real
+// and the Y components as imaginary.
+func (v V2[T]) Cmul(w V2[T]) V2[T] { return V2[T]{w.X*v.X - w.Y*v.Y, w.Y*v.X + w.X*v.Y} }
+
+// Rot rotates the vector by the angle t. If T is an integer type, the result
+// is rounded to the nearest integer.
+func (v V2[T]) Rot(t float64) V2[T] { return V2FromF2[T](v.F2().Rot(t)) }
+
+// RotAbout rotates the vector by the angle t around the vector b.
+func (v V2[T]) RotAbout(t float64, b V2[T]) V2[T] { return v.Sub(b).Rot(t).Add(b) }
+
+// Swap switches x and y components.
+func (v V2[T]) Swap() V2[T] { return V2[T]{v.Y, v.X} }
+
+// Dir returns the general direction of v (Up, Down, Left, Right).
+func (v V2[T]) Dir() Direction { return v.F2().Dir() }
+
+// InRect tests if v is in the rectangle ul-dr.
+func (v V2[T]) InRect(ul, dr V2[T]) bool {
+	return v.X >= ul.X && v.X <= dr.X && v.Y >= ul.Y && v.Y <= dr.Y
+}
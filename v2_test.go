@@ -0,0 +1,69 @@
+// Copyright 2016 Josh Deprez
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vec
+
+import (
+	"math"
+	"math/bits"
+	"testing"
+)
+
+func TestV2Conversions(t *testing.T) {
+	if got, want := V2FromI2[int32](I2{3, -4}), (V2[int32]{3, -4}); got != want {
+		t.Errorf("V2FromI2: got %v want %v", got, want)
+	}
+	if got, want := V2FromF2[int64](F2{2.6, -2.6}), (V2[int64]{3, -3}); got != want {
+		t.Errorf("V2FromF2[int64]: got %v want %v", got, want)
+	}
+	if got, want := V2FromF2[float32](F2{2.5, -2.5}), (V2[float32]{2.5, -2.5}); got != want {
+		t.Errorf("V2FromF2[float32]: got %v want %v", got, want)
+	}
+	if got, want := (V2[float32]{1.4, -1.6}).I2(), (I2{1, -2}); got != want {
+		t.Errorf("I2: got %v want %v", got, want)
+	}
+	if big := int64(1<<62 + 1); bits.UintSize == 64 {
+		if got := (V2[int64]{big, 3}).I2(); int64(got.X) != big || got.Y != 3 {
+			t.Errorf("I2 (large int64): got %v want %d,3", got, big)
+		}
+	}
+	if got, want := (V2[int16]{7, 8}).F2(), (F2{7, 8}); got != want {
+		t.Errorf("F2: got %v want %v", got, want)
+	}
+}
+
+func TestV2Methods(t *testing.T) {
+	v, w := V2[int32]{3, -4}, V2[int32]{-1, 2}
+	if got, want := v.Dot(w), int32(-11); got != want {
+		t.Errorf("Dot: got %d want %d", got, want)
+	}
+	if got, want := v.Norm(), 5.0; got != want {
+		t.Errorf("Norm: got %f want %f", got, want)
+	}
+	if got, want := v.Sgn(), (V2[int32]{1, -1}); got != want {
+		t.Errorf("Sgn: got %v want %v", got, want)
+	}
+	if got, want := v.ClampLo(w).ClampHi(V2[int32]{2, 2}), (V2[int32]{2, 2}); got != want {
+		t.Errorf("Clamp: got %v want %v", got, want)
+	}
+	if got, want := v.Rot(math.Pi/2), (V2[int32]{4, 3}); got != want {
+		t.Errorf("Rot: got %v want %v", got, want)
+	}
+	if got, want := (V2[float32]{-2, 0.5}).Sgn(), (V2[float32]{-1, 1}); got != want {
+		t.Errorf("Sgn: got %v want %v", got, want)
+	}
+	if got, want := (V2[float64]{0, 3}).Dir(), Down; got != want {
+		t.Errorf("Dir: got %v want %v", got, want)
+	}
+}